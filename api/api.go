@@ -0,0 +1,285 @@
+// Package api serves what the client gets from MAL as a local JSON API, in
+// the schema of schema.go.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/izzanzahrial/tui/url"
+	"golang.org/x/sync/singleflight"
+)
+
+// listTTL is how long a list is answered from memory. Details are cached by
+// the client itself, for as long as the TUI keeps them.
+const listTTL = 5 * time.Minute
+
+// The largest lists MAL serves in one page.
+const (
+	maxRankLimit   = 500
+	maxSearchLimit = 100
+)
+
+// Logger receives a line for every request served.
+type Logger interface {
+	Infof(format string, v ...any)
+	Errorf(format string, v ...any)
+}
+
+// Server answers:
+//
+//	GET /v1/rank?type=airing&limit=100&offset=0
+//	GET /v1/anime/{id}
+//	GET /v1/search?q=frieren&limit=10
+//	GET /v1/season/{year}/{season}?sort=score&limit=100&offset=0
+//
+// Every consumer goes through the same client, so they share its rate
+// limit and its caches, and the same list asked for twice at once is only
+// fetched once.
+type Server struct {
+	client *url.Client
+	log    Logger
+	mux    *http.ServeMux
+
+	flight singleflight.Group
+	mu     sync.Mutex
+	lists  map[string]cachedList
+}
+
+type cachedList struct {
+	list      List
+	fetchedAt time.Time
+}
+
+func New(client *url.Client, lg Logger) *Server {
+	s := &Server{
+		client: client,
+		log:    lg,
+		mux:    http.NewServeMux(),
+		lists:  make(map[string]cachedList),
+	}
+	s.mux.HandleFunc("GET /v1/rank", s.rank)
+	s.mux.HandleFunc("GET /v1/anime/{id}", s.detail)
+	s.mux.HandleFunc("GET /v1/search", s.search)
+	s.mux.HandleFunc("GET /v1/season/{year}/{season}", s.season)
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, errors.New("no such endpoint"))
+	})
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.mux.ServeHTTP(rec, r)
+	if rec.status >= http.StatusInternalServerError {
+		s.log.Errorf("%s %s: %d (%s)", r.Method, r.URL, rec.status, time.Since(start).Round(time.Millisecond))
+	} else {
+		s.log.Infof("%s %s: %d (%s)", r.Method, r.URL, rec.status, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+func (s *Server) rank(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	rankingType := url.Airing
+	if name := q.Get("type"); name != "" {
+		t, ok := url.ParseRankingType(name)
+		if !ok {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("unknown ranking type %q", name))
+			return
+		}
+		rankingType = t
+	}
+	limit, offset, err := page(q.Get("limit"), q.Get("offset"), maxRankLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	key := fmt.Sprintf("rank:%v:%d:%d", rankingType, limit, offset)
+	s.list(w, key, func() (List, error) {
+		data, err := s.client.AnimeRank(rankingType, &limit, &offset)
+		if err != nil {
+			return List{}, err
+		}
+		list := List{Data: make([]Anime, 0, len(data.AnimeRank))}
+		for _, a := range data.AnimeRank {
+			list.Data = append(list.Data, fromAnime(a.Anime, a.Rank.Rank, url.AnimePage(a.Anime.ID)))
+		}
+		return list, nil
+	})
+}
+
+func (s *Server) detail(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid ID %q", r.PathValue("id")))
+		return
+	}
+
+	d, err := s.client.AnimeDetail(id)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, fromDetail(d, url.AnimePage(d.ID)))
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := strings.TrimSpace(q.Get("q"))
+	// MAL refuses shorter queries
+	if len([]rune(query)) < 3 {
+		writeError(w, http.StatusBadRequest, errors.New("q needs at least 3 characters"))
+		return
+	}
+	limit, _, err := page(q.Get("limit"), "", maxSearchLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	key := fmt.Sprintf("search:%d:%s", limit, query)
+	s.list(w, key, func() (List, error) {
+		data, err := s.client.AnimeSearch(query, limit)
+		if err != nil {
+			return List{}, err
+		}
+		list := List{Data: make([]Anime, 0, len(data.Data))}
+		for _, a := range data.Data {
+			list.Data = append(list.Data, fromAnime(a.Anime, 0, url.AnimePage(a.Anime.ID)))
+		}
+		return list, nil
+	})
+}
+
+func (s *Server) season(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil || year < 1917 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year %q", r.PathValue("year")))
+		return
+	}
+	season := strings.ToLower(r.PathValue("season"))
+	if !slices.Contains(url.Seasons, season) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("season %q isn't one of %s", season, strings.Join(url.Seasons, ", ")))
+		return
+	}
+
+	q := r.URL.Query()
+	var sort url.SeasonSort
+	switch q.Get("sort") {
+	case "":
+	case "score":
+		sort = url.ByScore
+	case "members":
+		sort = url.ByMembers
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown sort %q, use score or members", q.Get("sort")))
+		return
+	}
+	limit, offset, err := page(q.Get("limit"), q.Get("offset"), maxRankLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	key := fmt.Sprintf("season:%d:%s:%v:%d:%d", year, season, sort, limit, offset)
+	s.list(w, key, func() (List, error) {
+		data, err := s.client.AnimeSeason(year, season, sort, limit, offset)
+		if err != nil {
+			return List{}, err
+		}
+		list := List{Data: make([]Anime, 0, len(data.Data))}
+		for _, a := range data.Data {
+			list.Data = append(list.Data, fromAnime(a.Anime, 0, url.AnimePage(a.Anime.ID)))
+		}
+		return list, nil
+	})
+}
+
+// list answers with the list fetch returns, from the cache when the same
+// request was answered recently. The key is made of the parsed parameters,
+// so ones the API doesn't know can't add entries.
+func (s *Server) list(w http.ResponseWriter, key string, fetch func() (List, error)) {
+	s.mu.Lock()
+	cached, ok := s.lists[key]
+	if ok && time.Since(cached.fetchedAt) > listTTL {
+		delete(s.lists, key)
+		ok = false
+	}
+	s.mu.Unlock()
+	if ok {
+		writeJSON(w, http.StatusOK, cached.list)
+		return
+	}
+
+	v, err, _ := s.flight.Do(key, func() (any, error) {
+		list, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		// entries asked for once would stay otherwise
+		for k, cached := range s.lists {
+			if time.Since(cached.fetchedAt) > listTTL {
+				delete(s.lists, k)
+			}
+		}
+		s.lists[key] = cachedList{list: list, fetchedAt: time.Now()}
+		s.mu.Unlock()
+		return list, nil
+	})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v.(List))
+}
+
+// page parses the limit and offset parameters, either may be empty.
+func page(limit, offset string, maxLimit int) (int, int, error) {
+	l, o := 0, 0
+	if limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxLimit {
+			return 0, 0, fmt.Errorf("limit has to be between 1 and %d", maxLimit)
+		}
+		l = n
+	}
+	if offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("offset can't be negative")
+		}
+		o = n
+	}
+	return l, o, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, Error{Error: err.Error()})
+}
+
+// statusRecorder remembers the status written, for the log.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
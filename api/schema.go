@@ -0,0 +1,175 @@
+package api
+
+import (
+	"github.com/izzanzahrial/tui/entity"
+)
+
+// The schema is ours rather than MAL's: fields are only ever added to it,
+// names don't follow upstream renames and what MAL nests or sends in
+// several shapes comes out flat and typed.
+
+// Anime is an entry of a list, rank is 0 outside of the ranking.
+type Anime struct {
+	ID            int        `json:"id"`
+	Title         string     `json:"title"`
+	TitleEnglish  string     `json:"title_english,omitempty"`
+	TitleJapanese string     `json:"title_japanese,omitempty"`
+	Picture       string     `json:"picture,omitempty"`
+	MediaType     string     `json:"media_type"`
+	Episodes      int        `json:"episodes"`
+	Score         float64    `json:"score"`
+	Rank          int        `json:"rank,omitempty"`
+	Popularity    int        `json:"popularity"`
+	Members       int        `json:"members"`
+	Season        *Season    `json:"season,omitempty"`
+	Broadcast     *Broadcast `json:"broadcast,omitempty"`
+	URL           string     `json:"url"`
+}
+
+type Season struct {
+	Year int    `json:"year"`
+	Name string `json:"name"`
+}
+
+// Broadcast is in Japan Standard Time, like MAL has it.
+type Broadcast struct {
+	Day  string `json:"day"`
+	Time string `json:"time,omitempty"`
+}
+
+// List is what every list endpoint answers with.
+type List struct {
+	Data []Anime `json:"data"`
+}
+
+// Detail is everything the Detail page shows.
+type Detail struct {
+	Anime
+	Synopsis        string     `json:"synopsis"`
+	Background      string     `json:"background"`
+	Status          string     `json:"status"`
+	Rating          string     `json:"rating"`
+	StartDate       string     `json:"start_date,omitempty"`
+	Genres          []string   `json:"genres"`
+	Studios         []string   `json:"studios"`
+	Pictures        []string   `json:"pictures"`
+	Related         []Relation `json:"related"`
+	Recommendations []Ref      `json:"recommendations"`
+	Statistics      Statistics `json:"statistics"`
+}
+
+// Ref points to another anime, or manga in a relation.
+type Ref struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+type Relation struct {
+	Ref
+	Media    string `json:"media"`
+	Relation string `json:"relation"`
+}
+
+// Statistics counts the users per list, and the votes per score.
+type Statistics struct {
+	Watching    int `json:"watching"`
+	Completed   int `json:"completed"`
+	OnHold      int `json:"on_hold"`
+	Dropped     int `json:"dropped"`
+	PlanToWatch int `json:"plan_to_watch"`
+	// Scores is keyed by the score, "1" to "10", empty when MAL leaves it out.
+	Scores map[int]int `json:"scores"`
+}
+
+// Error is the body of every answer that isn't a 200.
+type Error struct {
+	Error string `json:"error"`
+}
+
+func fromAnime(a entity.Anime, rank int, link string) Anime {
+	out := Anime{
+		ID:            a.ID,
+		Title:         a.Title,
+		TitleEnglish:  a.AlternativeTitle.EngTitle,
+		TitleJapanese: a.AlternativeTitle.JpnTitle,
+		Picture:       a.Image.Picture,
+		MediaType:     string(a.MediaType),
+		Episodes:      a.Episodes,
+		Score:         a.Mean,
+		Rank:          rank,
+		Popularity:    a.Popularity,
+		Members:       a.Members,
+		URL:           link,
+	}
+	if a.StartSeason.Year != 0 {
+		out.Season = &Season{Year: a.StartSeason.Year, Name: a.StartSeason.Season}
+	}
+	if a.Broadcast.DayOfWeek != "" {
+		out.Broadcast = &Broadcast{Day: a.Broadcast.DayOfWeek, Time: a.Broadcast.StartTime}
+	}
+	return out
+}
+
+func fromDetail(d *entity.Detail, link string) Detail {
+	out := Detail{
+		Anime: Anime{
+			ID:            d.ID,
+			Title:         d.Title,
+			TitleEnglish:  d.AlternativeTitle.EngTitle,
+			TitleJapanese: d.AlternativeTitle.JpnTitle,
+			Picture:       d.Image.Picture,
+			MediaType:     string(d.MediaType),
+			Episodes:      d.Episodes,
+			Score:         d.Mean,
+			Rank:          d.Rank,
+			Popularity:    d.Popularity,
+			Members:       d.Members,
+			URL:           link,
+		},
+		Synopsis:   d.Synopsis,
+		Background: d.Background,
+		Status:     string(d.Status),
+		Rating:     string(d.Rating),
+		StartDate:  d.StartDate.String(),
+		// never null, so consumers can range over them as they are
+		Genres:          []string{},
+		Studios:         []string{},
+		Pictures:        []string{},
+		Related:         []Relation{},
+		Recommendations: []Ref{},
+		Statistics: Statistics{
+			Watching:    int(d.Statistics.Status.Watching),
+			Completed:   int(d.Statistics.Status.Completed),
+			OnHold:      int(d.Statistics.Status.OnHold),
+			Dropped:     int(d.Statistics.Status.Dropped),
+			PlanToWatch: int(d.Statistics.Status.PlanToWatch),
+			Scores:      make(map[int]int, len(d.Statistics.Scores)),
+		},
+	}
+	if d.StartSeason.Year != 0 {
+		out.Season = &Season{Year: d.StartSeason.Year, Name: d.StartSeason.Season}
+	}
+
+	for _, g := range d.Genres {
+		out.Genres = append(out.Genres, g.Name)
+	}
+	for _, s := range d.Studios {
+		out.Studios = append(out.Studios, s.Name)
+	}
+	for _, p := range d.Pictures {
+		out.Pictures = append(out.Pictures, p.Link())
+	}
+	for _, r := range d.RelatedAnimes {
+		out.Related = append(out.Related, Relation{Ref: Ref(r.Node), Media: "anime", Relation: r.RelationType})
+	}
+	for _, r := range d.RelatedMangas {
+		out.Related = append(out.Related, Relation{Ref: Ref(r.Node), Media: "manga", Relation: r.RelationType})
+	}
+	for _, r := range d.Recomendations {
+		out.Recommendations = append(out.Recommendations, Ref(r.Node))
+	}
+	for score, n := range d.Statistics.Scores {
+		out.Statistics.Scores[score] = int(n)
+	}
+	return out
+}
@@ -0,0 +1,217 @@
+package bookmark
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/izzanzahrial/tui/xdg"
+)
+
+const fileName = "bookmarks.json"
+
+type Bookmark struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	Note      string    `json:"note,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store keeps bookmarks in memory and writes them back to a json file
+// on every change, so nothing is lost if the program is killed. A change
+// only shows in memory once it's written.
+type Store struct {
+	mu        sync.RWMutex
+	path      string
+	bookmarks []Bookmark
+}
+
+// DefaultPath returns the bookmarks file under the XDG data directory.
+func DefaultPath() string {
+	return PathIn(xdg.DataDir())
+}
+
+// PathIn returns where the store is kept inside dir, e.g. a user's own
+// directory when serving over SSH.
+func PathIn(dir string) string {
+	return filepath.Join(dir, fileName)
+}
+
+// Open loads the store from path, a missing file is treated as an empty store.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmarks %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(b, &s.bookmarks); err != nil {
+		return nil, fmt.Errorf("failed to decode bookmarks %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// List returns a copy of every bookmark, most recent first.
+func (s *Store) List() []Bookmark {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := slices.Clone(s.bookmarks)
+	slices.SortStableFunc(list, func(a, b Bookmark) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return list
+}
+
+func (s *Store) Has(id int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.index(id) >= 0
+}
+
+// Toggle adds the anime if it's not bookmarked yet, otherwise removes it.
+// It reports whether the anime is bookmarked after the call, which is
+// still the state before it when the store can't be written.
+func (s *Store) Toggle(id int, title string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.index(id); i >= 0 {
+		if err := s.save(slices.Delete(slices.Clone(s.bookmarks), i, i+1)); err != nil {
+			return true, err
+		}
+		return false, nil
+	}
+
+	if err := s.save(append(slices.Clone(s.bookmarks), Bookmark{ID: id, Title: title, CreatedAt: time.Now()})); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Store) Remove(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(id)
+	if i < 0 {
+		return nil
+	}
+	return s.save(slices.Delete(slices.Clone(s.bookmarks), i, i+1))
+}
+
+func (s *Store) SetNote(id int, note string) error {
+	return s.update(id, func(b *Bookmark) { b.Note = note })
+}
+
+func (s *Store) SetTags(id int, tags []string) error {
+	return s.update(id, func(b *Bookmark) { b.Tags = tags })
+}
+
+// Export writes every bookmark to path using the same format as the store file.
+func (s *Store) Export(path string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return write(path, s.bookmarks)
+}
+
+// Import merges the bookmarks in path into the store, entries that already
+// exist are overwritten by the imported ones. It returns how many were imported.
+func (s *Store) Import(path string) (int, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var imported []Bookmark
+	if err := json.Unmarshal(b, &imported); err != nil {
+		return 0, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merged := slices.Clone(s.bookmarks)
+	// the file may list an anime more than once, the last one wins
+	index := make(map[int]int, len(merged))
+	for i, v := range merged {
+		index[v.ID] = i
+	}
+	for _, v := range imported {
+		if v.CreatedAt.IsZero() {
+			v.CreatedAt = time.Now()
+		}
+		if i, ok := index[v.ID]; ok {
+			merged[i] = v
+		} else {
+			index[v.ID] = len(merged)
+			merged = append(merged, v)
+		}
+	}
+
+	if err := s.save(merged); err != nil {
+		return 0, err
+	}
+	return len(imported), nil
+}
+
+func (s *Store) update(id int, fn func(*Bookmark)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(id)
+	if i < 0 {
+		return fmt.Errorf("bookmark %d not found", id)
+	}
+	updated := slices.Clone(s.bookmarks)
+	fn(&updated[i])
+	return s.save(updated)
+}
+
+// index must be called with the lock held.
+func (s *Store) index(id int) int {
+	return slices.IndexFunc(s.bookmarks, func(b Bookmark) bool { return b.ID == id })
+}
+
+// save writes bookmarks and only then makes them the store's, so a failed
+// write leaves the store as it was on disk. It must be called with the
+// lock held.
+func (s *Store) save(bookmarks []Bookmark) error {
+	if err := write(s.path, bookmarks); err != nil {
+		return err
+	}
+	s.bookmarks = bookmarks
+	return nil
+}
+
+func write(path string, bookmarks []Bookmark) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	b, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bookmarks: %w", err)
+	}
+
+	// write to a temporary file first so a crash never leaves a half written store
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,55 @@
+package bookmark
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportRepeatedID(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, fileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Toggle(1, "Frieren"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 isn't bookmarked yet and is listed twice, 1 is and is listed again
+	file := filepath.Join(dir, "import.json")
+	err = os.WriteFile(file, []byte(`[
+		{"id": 2, "title": "Mushishi"},
+		{"id": 1, "title": "Frieren", "note": "rewatch"},
+		{"id": 2, "title": "Mushishi", "note": "the last one"}
+	]`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := s.Import(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("Import reported %d bookmarks, want 3", n)
+	}
+
+	// what's saved has every anime once, as the file last had it
+	saved, err := Open(filepath.Join(dir, fileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, store := range []*Store{s, saved} {
+		notes := make(map[int]string)
+		for _, b := range store.List() {
+			if _, ok := notes[b.ID]; ok {
+				t.Errorf("anime %d is bookmarked more than once", b.ID)
+			}
+			notes[b.ID] = b.Note
+		}
+		if len(notes) != 2 || notes[1] != "rewatch" || notes[2] != "the last one" {
+			t.Errorf("bookmarks after the import are %+v", store.List())
+		}
+	}
+}
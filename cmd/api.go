@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/izzanzahrial/tui/api"
+	"github.com/izzanzahrial/tui/logger"
+	"github.com/izzanzahrial/tui/url"
+	"github.com/izzanzahrial/tui/xdg"
+)
+
+// serveAPICommand serves the client's data as JSON over HTTP, for tools
+// that want what the TUI shows without talking to MAL themselves.
+func serveAPICommand(args []string) error {
+	fs := flag.NewFlagSet("serve-api", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:23235", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	lg, err := logger.New(filepath.Join(xdg.StateDir(), "serve-api.log"), logger.DefaultCapacity)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer lg.Close()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           api.New(url.NewClient().SetLogger(lg), lg),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.ListenAndServe() }()
+
+	log.Printf("serving on http://%s/v1/", *addr)
+	lg.Infof("serving on %s", *addr)
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down, waiting up to %s for open requests", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
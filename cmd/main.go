@@ -1,27 +1,124 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izzanzahrial/tui/bookmark"
+	"github.com/izzanzahrial/tui/config"
+	"github.com/izzanzahrial/tui/logger"
 	"github.com/izzanzahrial/tui/model"
+	"github.com/izzanzahrial/tui/style"
 	"github.com/joho/godotenv"
 )
 
 func main() {
+	noColor := flag.Bool("no-color", false, "disable colors, same as setting NO_COLOR")
+	inline := flag.Bool("inline", false, "render below the prompt instead of using the full screen")
+	flag.Parse()
+
 	err := godotenv.Load()
 	if err != nil {
 		log.Fatalf("Error loading .env file : %v", err)
 	}
 
-	p := tea.NewProgram(
-		model.New(),
-		// tea.WithAltScreen(),       // use the full size of the terminal in its "alternate screen buffer"
+	cfg, err := config.Load(config.DefaultPath())
+	if err != nil {
+		log.Fatalf("Error loading config : %v", err)
+	}
+
+	store, err := bookmark.Open(bookmark.DefaultPath())
+	if err != nil {
+		log.Fatalf("Error opening bookmarks : %v", err)
+	}
+
+	if *noColor || os.Getenv("NO_COLOR") != "" {
+		cfg.NoColor = true
+	}
+
+	if *inline {
+		cfg.AltScreen = false
+	}
+
+	if flag.NArg() > 0 {
+		if err := runCommand(cfg, store, flag.Args()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	palettes, err := style.Palettes(style.DefaultThemeDir())
+	if err != nil {
+		log.Fatalf("Error loading themes : %v", err)
+	}
+
+	lg, err := logger.New(logger.DefaultPath(), logger.DefaultCapacity)
+	if err != nil {
+		log.Fatalf("Error opening log file : %v", err)
+	}
+	defer lg.Close()
+
+	m, err := model.New(store, lg, cfg, palettes)
+	if err != nil {
+		log.Fatalf("Error creating model : %v", err)
+	}
+
+	opts := []tea.ProgramOption{
 		tea.WithMouseCellMotion(), // turn on mouse support so we can track the mouse wheel
-	)
-	if _, err := p.Run(); err != nil {
+	}
+	if cfg.AltScreen {
+		// use the full size of the terminal in its "alternate screen buffer"
+		opts = append(opts, tea.WithAltScreen())
+	}
+
+	p := tea.NewProgram(m, opts...)
+
+	// anything printed to stderr would corrupt the screen, send it to the Logs page instead
+	log.SetOutput(lg)
+	_, err = p.Run()
+	log.SetOutput(os.Stderr)
+	if err != nil {
 		log.Fatalf("Alas, there's been an error: %v", err)
 		os.Exit(1)
 	}
 }
+
+// runCommand handles the non interactive subcommands.
+func runCommand(cfg config.Config, store *bookmark.Store, args []string) error {
+	switch args[0] {
+	case "bookmarks":
+		return bookmarksCommand(store, args[1:])
+	case "serve":
+		return serveCommand(cfg, args[1:])
+	case "serve-api":
+		return serveAPICommand(args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func bookmarksCommand(store *bookmark.Store, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: bookmarks export|import <file>")
+	}
+
+	switch args[0] {
+	case "export":
+		if err := store.Export(args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("exported %d bookmarks to %s\n", len(store.List()), args[1])
+	case "import":
+		n, err := store.Import(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("imported %d bookmarks from %s\n", n, args[1])
+	default:
+		return fmt.Errorf("unknown bookmarks command %q", args[0])
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/activeterm"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	"github.com/izzanzahrial/tui/bookmark"
+	"github.com/izzanzahrial/tui/config"
+	"github.com/izzanzahrial/tui/logger"
+	"github.com/izzanzahrial/tui/model"
+	"github.com/izzanzahrial/tui/style"
+	"github.com/izzanzahrial/tui/url"
+	"github.com/izzanzahrial/tui/xdg"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// shutdownTimeout is how long open sessions get to finish once the
+// server is asked to stop.
+const shutdownTimeout = 30 * time.Second
+
+// serveCommand hosts the TUI over SSH, one program per session. Every
+// session shares the API client, and with it the detail cache and the
+// rate limit, and gets the bookmarks of the key it logged in with and a
+// log of its own, so no one sees what the others do.
+func serveCommand(cfg config.Config, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:23234", "address to listen on")
+	hostKey := fs.String("host-key", filepath.Join(xdg.DataDir(), "ssh", "host_ed25519"), "host key, generated when missing")
+	authorized := fs.String("authorized-keys", "", "only let in the keys of this authorized_keys file, required unless listening on loopback")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// every session spends the same client ID and rate limit, strangers
+	// shouldn't get to do that
+	if *authorized == "" && !isLoopback(*addr) {
+		return fmt.Errorf("refusing to let any key in on %s, pass -authorized-keys or listen on loopback", *addr)
+	}
+
+	palettes, err := style.Palettes(style.DefaultThemeDir())
+	if err != nil {
+		return fmt.Errorf("failed to load themes: %w", err)
+	}
+
+	// the requests of every session, kept out of their Logs pages
+	trace, err := logger.New(filepath.Join(xdg.StateDir(), "serve.log"), logger.DefaultCapacity)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer trace.Close()
+
+	if err := os.MkdirAll(filepath.Dir(*hostKey), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for the host key: %w", err)
+	}
+
+	// there's no password to fall back on, every session has a key, and
+	// only loopback lets any in
+	auth := wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true })
+	if *authorized != "" {
+		auth = wish.WithAuthorizedKeys(*authorized)
+	}
+
+	sessions := &sessions{
+		cfg:      cfg,
+		palettes: palettes,
+		client:   url.NewClient().SetLogger(trace),
+		dir:      filepath.Join(xdg.DataDir(), "users"),
+		stores:   make(map[string]*bookmark.Store),
+	}
+
+	srv, err := wish.NewServer(
+		wish.WithAddress(*addr),
+		wish.WithHostKeyPath(*hostKey),
+		auth,
+		// the last one runs first
+		wish.WithMiddleware(
+			bm.Middleware(sessions.program),
+			activeterm.Middleware(),
+			logMiddleware(log.Default()),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create the SSH server: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.ListenAndServe() }()
+
+	if host, port, err := net.SplitHostPort(*addr); err == nil {
+		log.Printf("serving on %s, connect with: ssh -p %s %s", *addr, port, host)
+	}
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ssh.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down, waiting up to %s for open sessions", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// sessions creates the program of every SSH session, what they share
+// lives here.
+type sessions struct {
+	cfg      config.Config
+	palettes []style.Palette
+	client   *url.Client
+	dir      string
+
+	mu sync.Mutex
+	// stores is keyed by userKey, the sessions of one user share a store
+	// so they don't overwrite each other's bookmarks
+	stores map[string]*bookmark.Store
+}
+
+func (s *sessions) program(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
+	store, err := s.store(sess.PublicKey())
+	if err != nil {
+		log.Printf("%s: %v", sess.User(), err)
+		wish.Fatalln(sess, "failed to open your bookmarks")
+		return nil, nil
+	}
+
+	// the sequences have to go where the program writes, see bm.MakeOptions
+	pty, _, _ := sess.Pty()
+	var out io.Writer = sess
+	if !sess.EmulatedPty() && pty.Slave != nil {
+		out = pty.Slave
+	}
+
+	// in memory only, it's gone with the session
+	lg, err := logger.New("", logger.DefaultCapacity)
+	if err != nil {
+		log.Printf("%s: %v", sess.User(), err)
+		wish.Fatalln(sess, err)
+		return nil, nil
+	}
+
+	m, err := model.New(store, lg, s.cfg, s.palettes,
+		model.WithClient(s.client),
+		model.WithRenderer(bm.MakeRenderer(sess)),
+		model.WithRemote(out, append(sess.Environ(), "TERM="+pty.Term)),
+	)
+	if err != nil {
+		log.Printf("%s: %v", sess.User(), err)
+		wish.Fatalln(sess, err)
+		return nil, nil
+	}
+
+	opts := []tea.ProgramOption{tea.WithMouseCellMotion()}
+	if s.cfg.AltScreen {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	return m, opts
+}
+
+// store opens the bookmarks of the key, the first time it's seen.
+func (s *sessions) store(key ssh.PublicKey) (*bookmark.Store, error) {
+	id := userKey(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if store, ok := s.stores[id]; ok {
+		return store, nil
+	}
+	store, err := bookmark.Open(bookmark.PathIn(filepath.Join(s.dir, id)))
+	if err != nil {
+		return nil, err
+	}
+	s.stores[id] = store
+	return store, nil
+}
+
+// userKey names the directory of the key's state, unlike its fingerprint
+// it's safe to use in a path.
+func userKey(key ssh.PublicKey) string {
+	sum := sha256.Sum256(key.Marshal())
+	return hex.EncodeToString(sum[:])
+}
+
+// isLoopback reports whether addr only takes connections from this
+// machine, an empty host listens on every interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// logMiddleware records who connects, with the fingerprint ssh-keygen -l
+// shows for their key, and for how long. It's the server's log, no
+// session's Logs page shows it.
+func logMiddleware(lg *log.Logger) wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(sess ssh.Session) {
+			start := time.Now()
+			fingerprint := gossh.FingerprintSHA256(sess.PublicKey())
+			lg.Printf("%s (%s) connected from %s", sess.User(), fingerprint, sess.RemoteAddr())
+			next(sess)
+			lg.Printf("%s (%s) disconnected after %s", sess.User(), fingerprint, time.Since(start).Round(time.Second))
+		}
+	}
+}
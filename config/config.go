@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/izzanzahrial/tui/xdg"
+)
+
+const fileName = "config.json"
+
+type Config struct {
+	// RankColumns are the columns shown in the rank table, in order.
+	// Available: rank, title, japanese_title, score, popularity, members,
+	// episodes, season, media_type.
+	RankColumns []string `json:"rank_columns"`
+
+	// Keys overrides key bindings by name, e.g. {"global.quit": ["q", "ctrl+c"]}.
+	// An empty list disables the binding. See model.KeyMaps for every name.
+	Keys map[string][]string `json:"keys"`
+
+	// Theme is the name of the theme used at startup, either a built-in one
+	// (dark, light, high-contrast, catppuccin) or a file in the themes directory.
+	Theme string `json:"theme"`
+
+	// NoColor drops every color and uses bold, underline and reverse instead,
+	// it's also turned on by the NO_COLOR environment variable or --no-color.
+	NoColor bool `json:"no_color"`
+
+	// AltScreen runs the app full screen in the terminal's alternate screen,
+	// set it to false, or pass --inline, to render below the prompt instead.
+	AltScreen bool `json:"alt_screen"`
+
+	// SplitWidth is the terminal width from which the Rank page shows a
+	// detail preview of the selected anime next to the table, 0 turns it off.
+	SplitWidth int `json:"split_width"`
+}
+
+func Default() Config {
+	return Config{
+		RankColumns: []string{"rank", "title", "score", "members", "episodes", "media_type", "season", "japanese_title"},
+		Theme:       "dark",
+		AltScreen:   true,
+		SplitWidth:  140,
+	}
+}
+
+// DefaultPath returns the config file under the XDG config directory.
+func DefaultPath() string {
+	return filepath.Join(xdg.ConfigDir(), fileName)
+}
+
+// Load reads the config at path on top of the defaults,
+// a missing file simply means the defaults are used.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
@@ -5,6 +5,18 @@ type Anime struct {
 	Title            string           `json:"title"`
 	Image            Image            `json:"main_picture"`
 	AlternativeTitle AlternativeTitle `json:"alternative_titles"`
+	Mean             float64          `json:"mean"`
+	Popularity       int              `json:"popularity"`
+	Members          int              `json:"num_list_users"`
+	Episodes         int              `json:"num_episodes"`
+	MediaType        MediaType        `json:"media_type"`
+	StartSeason      Season           `json:"start_season"`
+	Broadcast        Broadcast        `json:"broadcast"`
+}
+
+type Season struct {
+	Year   int    `json:"year"`
+	Season string `json:"season"`
 }
 
 type Image struct {
@@ -23,3 +35,19 @@ type AnimeRank struct {
 type Data struct {
 	AnimeRank []AnimeRank `json:"data"`
 }
+
+// Broadcast is when a new episode airs, in Japan Standard Time.
+type Broadcast struct {
+	// DayOfWeek is the lowercase english weekday, e.g. "saturday".
+	DayOfWeek string `json:"day_of_the_week"`
+	// StartTime is "HH:MM", empty when MAL doesn't know it.
+	StartTime string `json:"start_time"`
+}
+
+// AnimeList is what the search and the seasonal list return, the same as a
+// ranking without the ranks.
+type AnimeList struct {
+	Data []struct {
+		Anime Anime `json:"node"`
+	} `json:"data"`
+}
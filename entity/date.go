@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date is a date MAL may only know part of, e.g. "2024" or "2024-10" for
+// an anime that's announced but not scheduled. The parts it doesn't know
+// are 0, a zero Date is an unknown one.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// dateLayouts are the forms MAL sends, by how many parts they have.
+var dateLayouts = []string{"2006", "2006-01", "2006-01-02"}
+
+// ParseDate reads the forms MAL sends: YYYY, YYYY-MM or YYYY-MM-DD. An
+// empty string is an unknown date.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+	parts := strings.Count(s, "-")
+	if parts >= len(dateLayouts) {
+		return Date{}, fmt.Errorf("invalid date %q", s)
+	}
+	t, err := time.Parse(dateLayouts[parts], s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q", s)
+	}
+
+	d := Date{Year: t.Year()}
+	if parts > 0 {
+		d.Month = t.Month()
+	}
+	if parts > 1 {
+		d.Day = t.Day()
+	}
+	return d, nil
+}
+
+func (d Date) IsZero() bool { return d.Year == 0 }
+
+// String is the date as MAL sends it, with only the parts it knows.
+func (d Date) String() string {
+	switch {
+	case d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	case d.Day == 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Compare orders dates like their text would, a part that isn't known
+// comes before any that is.
+func (d Date) Compare(o Date) int {
+	return cmp.Or(
+		cmp.Compare(d.Year, o.Year),
+		cmp.Compare(d.Month, o.Month),
+		cmp.Compare(d.Day, o.Day),
+	)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(*s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
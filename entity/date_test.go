@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Date
+		wantErr bool
+	}{
+		{in: "", want: Date{}},
+		{in: "2024", want: Date{Year: 2024}},
+		{in: "2024-10", want: Date{Year: 2024, Month: time.October}},
+		{in: "2023-09-29", want: Date{Year: 2023, Month: time.September, Day: 29}},
+		{in: "24", wantErr: true},
+		{in: "2024-1", wantErr: true},
+		{in: "2024-13", wantErr: true},
+		{in: "2024-02-30", wantErr: true},
+		{in: "2024-10-05-01", wantErr: true},
+		{in: "2024/10/05", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDate(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDate(%q) error = %v, want error %t", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDate(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+		// what parses prints back the same
+		if err == nil && got.String() != tt.in {
+			t.Errorf("ParseDate(%q).String() = %q", tt.in, got.String())
+		}
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want Date
+		// out is what the date encodes to, json when empty
+		out string
+	}{
+		{name: "day", json: `"2023-09-29"`, want: Date{Year: 2023, Month: time.September, Day: 29}},
+		{name: "month", json: `"2024-10"`, want: Date{Year: 2024, Month: time.October}},
+		{name: "year", json: `"2024"`, want: Date{Year: 2024}},
+		{name: "empty", json: `""`, want: Date{}},
+		{name: "null", json: `null`, want: Date{}, out: `""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Date
+			if err := json.Unmarshal([]byte(tt.json), &d); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", tt.json, err)
+			}
+			if d != tt.want {
+				t.Fatalf("Unmarshal(%s) = %#v, want %#v", tt.json, d, tt.want)
+			}
+
+			b, err := json.Marshal(d)
+			if err != nil {
+				t.Fatalf("Marshal(%#v): %v", d, err)
+			}
+			want := tt.out
+			if want == "" {
+				want = tt.json
+			}
+			if string(b) != want {
+				t.Errorf("Marshal(%#v) = %s, want %s", d, b, want)
+			}
+		})
+	}
+}
+
+func TestDateJSONInvalid(t *testing.T) {
+	for _, in := range []string{`"2024-13"`, `"soon"`, `2024`, `{}`} {
+		var d Date
+		if err := json.Unmarshal([]byte(in), &d); err == nil {
+			t.Errorf("Unmarshal(%s) = %#v, want an error", in, d)
+		}
+	}
+}
+
+func TestDateZero(t *testing.T) {
+	var d Date
+	if !d.IsZero() {
+		t.Error("the zero Date isn't zero")
+	}
+	if d.String() != "" {
+		t.Errorf("zero Date.String() = %q, want it empty", d.String())
+	}
+
+	// a zero date in an entity survives the round trip
+	in := MangaDetail{StartDate: Date{Year: 2020}}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out MangaDetail
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.StartDate != in.StartDate || !out.EndDate.IsZero() {
+		t.Errorf("round trip gave start %#v and end %#v", out.StartDate, out.EndDate)
+	}
+}
+
+func TestDateCompare(t *testing.T) {
+	dates := []Date{
+		{},
+		{Year: 2023},
+		{Year: 2023, Month: time.September},
+		{Year: 2023, Month: time.September, Day: 29},
+		{Year: 2023, Month: time.October},
+		{Year: 2024},
+	}
+	for i := range dates {
+		for j := range dates {
+			got := dates[i].Compare(dates[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got != want {
+				t.Errorf("%q.Compare(%q) = %d, want %d", dates[i], dates[j], got, want)
+			}
+		}
+	}
+}
+
+func TestDetailRoundTrip(t *testing.T) {
+	d := recordedDetail(t, nil)
+	want := Date{Year: 2023, Month: time.September, Day: 29}
+	if d.StartDate != want {
+		t.Errorf("StartDate = %#v, want %#v", d.StartDate, want)
+	}
+
+	again, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Detail
+	if err := json.Unmarshal(again, &back); err != nil {
+		t.Fatalf("failed to decode the encoded detail: %v", err)
+	}
+	if !reflect.DeepEqual(back, d) {
+		t.Errorf("round trip changed the detail:\n%+v\nwas\n%+v", back, d)
+	}
+}
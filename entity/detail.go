@@ -1,19 +1,29 @@
 package entity
 
 type Detail struct {
-	Title            string           `json:"Title"`
+	ID               int              `json:"id"`
+	Title            string           `json:"title"`
+	Image            Image            `json:"main_picture"`
 	AlternativeTitle AlternativeTitle `json:"alternative_titles"`
-	StartDate        string           `json:"start_date"`
+	StartDate        Date             `json:"start_date"`
 	Synopsis         string           `json:"synopsis"`
+	Mean             float64          `json:"mean"`
 	Rank             int              `json:"rank"`
 	Popularity       int              `json:"popularity"`
-	Status           string           `json:"status"`
+	Members          int              `json:"num_list_users"`
+	MediaType        MediaType        `json:"media_type"`
+	Episodes         int              `json:"num_episodes"`
+	StartSeason      Season           `json:"start_season"`
+	Status           Status           `json:"status"`
 	Genres           []Genre          `json:"genres"`
-	Rating           string           `json:"rating`
-	Background       string           `json:"background,omitzero"`
+	Rating           Rating           `json:"rating"`
+	Background       string           `json:"background"`
+	Pictures         []Picture        `json:"pictures"`
 	RelatedAnimes    []RelatedAnime   `json:"related_anime"`
+	RelatedMangas    []RelatedManga   `json:"related_manga"`
 	Recomendations   []Recommendation `json:"recommendations"`
 	Studios          []Studio         `json:"studios"`
+	Statistics       Statistics       `json:"statistics"`
 }
 
 // we only care about the english and japan alternative title
@@ -33,6 +43,20 @@ type RelatedAnime struct {
 	RelationType string `json:"relation_type_formatted"`
 }
 
+// Picture is one of the additional pictures of an anime, in both sizes MAL offers.
+type Picture struct {
+	Medium string `json:"medium"`
+	Large  string `json:"large"`
+}
+
+// Link prefers the large picture, some only come in medium.
+func (p Picture) Link() string {
+	if p.Large != "" {
+		return p.Large
+	}
+	return p.Medium
+}
+
 type Recommendation struct {
 	Node Node `json:"node"`
 }
@@ -0,0 +1,115 @@
+package entity
+
+import "strings"
+
+// The enums keep the value MAL sends, so they survive a round trip through
+// JSON unchanged, and one MAL adds later still decodes and prints.
+
+// Status is where an anime is in its airing, or a manga in its publication.
+type Status string
+
+const (
+	StatusFinishedAiring  Status = "finished_airing"
+	StatusCurrentlyAiring Status = "currently_airing"
+	StatusNotYetAired     Status = "not_yet_aired"
+
+	StatusFinished            Status = "finished"
+	StatusCurrentlyPublishing Status = "currently_publishing"
+	StatusNotYetPublished     Status = "not_yet_published"
+	StatusOnHiatus            Status = "on_hiatus"
+	StatusDiscontinued        Status = "discontinued"
+)
+
+var statusNames = map[Status]string{
+	StatusFinishedAiring:      "Finished airing",
+	StatusCurrentlyAiring:     "Currently airing",
+	StatusNotYetAired:         "Not yet aired",
+	StatusFinished:            "Finished",
+	StatusCurrentlyPublishing: "Publishing",
+	StatusNotYetPublished:     "Not yet published",
+	StatusOnHiatus:            "On hiatus",
+	StatusDiscontinued:        "Discontinued",
+}
+
+func (s Status) String() string { return enumName(statusNames, s) }
+
+// Rating is the age rating of an anime.
+type Rating string
+
+const (
+	RatingG     Rating = "g"
+	RatingPG    Rating = "pg"
+	RatingPG13  Rating = "pg_13"
+	RatingR     Rating = "r"
+	RatingRPlus Rating = "r+"
+	RatingRx    Rating = "rx"
+)
+
+var ratingNames = map[Rating]string{
+	RatingG:     "G - All Ages",
+	RatingPG:    "PG - Children",
+	RatingPG13:  "PG-13 - Teens 13 or older",
+	RatingR:     "R - 17+ (violence & profanity)",
+	RatingRPlus: "R+ - Mild Nudity",
+	RatingRx:    "Rx - Hentai",
+}
+
+func (r Rating) String() string { return enumName(ratingNames, r) }
+
+// MediaType is the format of an anime, e.g. tv or movie, or of a manga,
+// e.g. light_novel.
+type MediaType string
+
+const (
+	MediaUnknown   MediaType = "unknown"
+	MediaTV        MediaType = "tv"
+	MediaOVA       MediaType = "ova"
+	MediaMovie     MediaType = "movie"
+	MediaSpecial   MediaType = "special"
+	MediaONA       MediaType = "ona"
+	MediaMusic     MediaType = "music"
+	MediaTVSpecial MediaType = "tv_special"
+	MediaCM        MediaType = "cm"
+	MediaPV        MediaType = "pv"
+
+	MediaManga      MediaType = "manga"
+	MediaNovel      MediaType = "novel"
+	MediaLightNovel MediaType = "light_novel"
+	MediaOneShot    MediaType = "one_shot"
+	MediaDoujinshi  MediaType = "doujinshi"
+	MediaManhwa     MediaType = "manhwa"
+	MediaManhua     MediaType = "manhua"
+	MediaOEL        MediaType = "oel"
+)
+
+var mediaTypeNames = map[MediaType]string{
+	MediaUnknown:    "Unknown",
+	MediaTV:         "TV",
+	MediaOVA:        "OVA",
+	MediaMovie:      "Movie",
+	MediaSpecial:    "Special",
+	MediaONA:        "ONA",
+	MediaMusic:      "Music",
+	MediaTVSpecial:  "TV Special",
+	MediaCM:         "CM",
+	MediaPV:         "PV",
+	MediaManga:      "Manga",
+	MediaNovel:      "Novel",
+	MediaLightNovel: "Light Novel",
+	MediaOneShot:    "One-shot",
+	MediaDoujinshi:  "Doujinshi",
+	MediaManhwa:     "Manhwa",
+	MediaManhua:     "Manhua",
+	MediaOEL:        "OEL",
+}
+
+func (m MediaType) String() string { return enumName(mediaTypeNames, m) }
+
+// enumName falls back to the raw value, made a little more readable, for
+// the values MAL adds before we know about them.
+func enumName[E ~string](names map[E]string, e E) string {
+	if name, ok := names[e]; ok {
+		return name
+	}
+	return strings.ReplaceAll(string(e), "_", " ")
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// recordedDetailFile is MAL's answer the url conformance tests check the
+// entities against, shared so the two can't drift apart.
+const recordedDetailFile = "../url/testdata/detail.json"
+
+// recordedDetail decodes the recorded detail answer, with the fields in
+// replace swapped in first.
+func recordedDetail(t *testing.T, replace map[string]any) Detail {
+	t.Helper()
+
+	b, err := os.ReadFile(recordedDetailFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replace) > 0 {
+		var raw map[string]any
+		if err := json.Unmarshal(b, &raw); err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range replace {
+			raw[k] = v
+		}
+		if b, err = json.Marshal(raw); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var d Detail
+	if err := json.Unmarshal(b, &d); err != nil {
+		t.Fatalf("failed to decode the recorded detail: %v", err)
+	}
+	return d
+}
+
+func TestEnumsKnown(t *testing.T) {
+	d := recordedDetail(t, nil)
+
+	tests := []struct {
+		name    string
+		got     string
+		raw     string
+		want    string
+		wantRaw string
+	}{
+		{name: "status", got: d.Status.String(), raw: string(d.Status), want: "Finished airing", wantRaw: string(StatusFinishedAiring)},
+		{name: "rating", got: d.Rating.String(), raw: string(d.Rating), want: "PG-13 - Teens 13 or older", wantRaw: string(RatingPG13)},
+		{name: "media type", got: d.MediaType.String(), raw: string(d.MediaType), want: "TV", wantRaw: string(MediaTV)},
+	}
+	for _, tt := range tests {
+		if tt.raw != tt.wantRaw {
+			t.Errorf("%s decoded as %q, want %q", tt.name, tt.raw, tt.wantRaw)
+		}
+		if tt.got != tt.want {
+			t.Errorf("%s prints %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestEnumsUnknown(t *testing.T) {
+	d := recordedDetail(t, map[string]any{
+		"status":     "on_break",
+		"rating":     "r18",
+		"media_type": "tv_short",
+	})
+
+	if got, want := d.Status.String(), "on break"; got != want {
+		t.Errorf("unknown status prints %q, want %q", got, want)
+	}
+	if got, want := d.Rating.String(), "r18"; got != want {
+		t.Errorf("unknown rating prints %q, want %q", got, want)
+	}
+	if got, want := d.MediaType.String(), "tv short"; got != want {
+		t.Errorf("unknown media type prints %q, want %q", got, want)
+	}
+
+	// the value MAL sent is kept, not only its name
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		Status    string `json:"status"`
+		Rating    string `json:"rating"`
+		MediaType string `json:"media_type"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw.Status != "on_break" || raw.Rating != "r18" || raw.MediaType != "tv_short" {
+		t.Errorf("round trip gave %+v", raw)
+	}
+}
+
+func TestEnumsEmpty(t *testing.T) {
+	var (
+		s Status
+		r Rating
+		m MediaType
+	)
+	if s.String() != "" || r.String() != "" || m.String() != "" {
+		t.Errorf("empty values print %q, %q and %q, want them empty", s, r, m)
+	}
+}
@@ -0,0 +1,24 @@
+package entity
+
+// Franchise is every anime reachable from Root through related_anime,
+// as far as the walk was allowed to go.
+type Franchise struct {
+	Root    int
+	Entries []FranchiseEntry
+	// Truncated is set when the depth or size limit left relations unvisited
+	Truncated bool
+}
+
+// FranchiseEntry is one anime of the franchise with the relation it was
+// first reached through, the root has no parent.
+type FranchiseEntry struct {
+	ID               int
+	Title            string
+	AlternativeTitle AlternativeTitle
+	MediaType        MediaType
+	StartDate        Date
+	Episodes         int
+	Depth            int
+	Parent           int
+	Relation         string
+}
@@ -0,0 +1,85 @@
+package entity
+
+type Manga struct {
+	ID               int              `json:"id"`
+	Title            string           `json:"title"`
+	Image            Image            `json:"main_picture"`
+	AlternativeTitle AlternativeTitle `json:"alternative_titles"`
+	Mean             float64          `json:"mean"`
+	Popularity       int              `json:"popularity"`
+	Members          int              `json:"num_list_users"`
+	Volumes          int              `json:"num_volumes"`
+	Chapters         int              `json:"num_chapters"`
+	MediaType        MediaType        `json:"media_type"`
+	StartDate        Date             `json:"start_date"`
+}
+
+type MangaRank struct {
+	Manga Manga   `json:"node"`
+	Rank  Ranking `json:"ranking"`
+}
+
+type MangaData struct {
+	MangaRank []MangaRank `json:"data"`
+}
+
+// MangaList is what the search returns, the same as a ranking without the ranks.
+type MangaList struct {
+	Data []struct {
+		Manga Manga `json:"node"`
+	} `json:"data"`
+}
+
+type MangaDetail struct {
+	ID               int              `json:"id"`
+	Title            string           `json:"title"`
+	AlternativeTitle AlternativeTitle `json:"alternative_titles"`
+	StartDate        Date             `json:"start_date"`
+	EndDate          Date             `json:"end_date"`
+	Synopsis         string           `json:"synopsis"`
+	Background       string           `json:"background"`
+	Mean             float64          `json:"mean"`
+	Rank             int              `json:"rank"`
+	Popularity       int              `json:"popularity"`
+	Members          int              `json:"num_list_users"`
+	MediaType        MediaType        `json:"media_type"`
+	Status           Status           `json:"status"`
+	Genres           []Genre          `json:"genres"`
+	Volumes          int              `json:"num_volumes"`
+	Chapters         int              `json:"num_chapters"`
+	Authors          []Author         `json:"authors"`
+	Serialization    []Serialization  `json:"serialization"`
+	RelatedAnimes    []RelatedAnime   `json:"related_anime"`
+	RelatedMangas    []RelatedManga   `json:"related_manga"`
+	Recomendations   []Recommendation `json:"recommendations"`
+}
+
+type RelatedManga struct {
+	Node         Node   `json:"node"`
+	RelationType string `json:"relation_type_formatted"`
+}
+
+type Author struct {
+	Node struct {
+		ID        int    `json:"id"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	} `json:"node"`
+	Role string `json:"role"`
+}
+
+func (a Author) GetName() string {
+	if a.Node.FirstName == "" {
+		return a.Node.LastName
+	}
+	return a.Node.FirstName + " " + a.Node.LastName
+}
+
+type Serialization struct {
+	Node struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"node"`
+}
+
+func (s Serialization) GetName() string { return s.Node.Name }
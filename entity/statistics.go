@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+type Statistics struct {
+	Status       StatusCounts `json:"status"`
+	NumListUsers int          `json:"num_list_users"`
+	// Scores is the number of votes per score, 1 to 10, when MAL includes it.
+	Scores map[int]Count `json:"scores"`
+}
+
+// StatusCounts is how many users have the anime in each list.
+type StatusCounts struct {
+	Watching    Count `json:"watching"`
+	Completed   Count `json:"completed"`
+	OnHold      Count `json:"on_hold"`
+	Dropped     Count `json:"dropped"`
+	PlanToWatch Count `json:"plan_to_watch"`
+}
+
+// Count is a number MAL sometimes sends as a string, e.g. "watching": "1234".
+type Count int
+
+func (c *Count) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s == "" {
+			*c = 0
+			return nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*c = Count(n)
+		return nil
+	}
+
+	var n int
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*c = Count(n)
+	return nil
+}
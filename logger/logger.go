@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/izzanzahrial/tui/xdg"
+)
+
+const (
+	fileName = "anime-tui.log"
+
+	// DefaultCapacity is how many entries are kept in memory.
+	DefaultCapacity = 500
+	// maxFileSize is the size after which the log file is rotated.
+	maxFileSize = 1 << 20
+	// maxBackups is how many rotated files are kept, e.g. anime-tui.log.1 up to .3
+	maxBackups = 3
+)
+
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
+
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("%s %-5s %s", e.Time.Format(time.DateTime), e.Level, e.Message)
+}
+
+// Logger keeps the last entries in a ring buffer for the logs page and
+// appends every entry to a log file, the terminal is never written to
+// since it would corrupt the TUI.
+type Logger struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int
+	full    bool
+	// seq counts every entry ever logged, unlike the ring it keeps growing
+	seq uint64
+
+	path string
+	file *os.File
+	size int64
+}
+
+// DefaultPath returns the log file under the XDG state directory.
+func DefaultPath() string {
+	return filepath.Join(xdg.StateDir(), fileName)
+}
+
+// New creates a logger keeping capacity entries in memory and writing to
+// path, an empty path keeps the entries in memory only.
+func New(path string, capacity int) (*Logger, error) {
+	l := &Logger{
+		entries: make([]Entry, max(1, capacity)),
+		path:    path,
+	}
+
+	if path == "" {
+		return l, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Logger) Infof(format string, v ...any)  { l.log(LevelInfo, format, v...) }
+func (l *Logger) Warnf(format string, v ...any)  { l.log(LevelWarn, format, v...) }
+func (l *Logger) Errorf(format string, v ...any) { l.log(LevelError, format, v...) }
+
+// Write lets the logger be used with log.SetOutput, every write is an info entry.
+func (l *Logger) Write(p []byte) (int, error) {
+	l.log(LevelInfo, "%s", strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+// Entries returns the entries in memory at level or above, oldest first.
+func (l *Logger) Entries(level Level) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ordered := l.entries[:l.next]
+	if l.full {
+		ordered = append(append([]Entry(nil), l.entries[l.next:]...), l.entries[:l.next]...)
+	}
+
+	var entries []Entry
+	for _, e := range ordered {
+		if e.Level >= level {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Seq returns how many entries were logged so far, a change means there
+// is something new even once the ring is full and its length stays the same.
+func (l *Logger) Seq() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.seq
+}
+
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *Logger) log(level Level, format string, v ...any) {
+	e := Entry{Time: time.Now(), Level: level, Message: fmt.Sprintf(format, v...)}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[l.next] = e
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+	l.seq++
+
+	if l.file == nil {
+		return
+	}
+
+	// a failing log file is not worth crashing for, the entry is still in memory
+	n, _ := fmt.Fprintln(l.file, e.String())
+	l.size += int64(n)
+	if l.size >= maxFileSize {
+		_ = l.rotate()
+	}
+}
+
+// open must be called with the lock held.
+func (l *Logger) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	l.file = f
+	l.size = info.Size()
+	return nil
+}
+
+// rotate shifts anime-tui.log to anime-tui.log.1, .1 to .2 and so on,
+// dropping the oldest one. It must be called with the lock held.
+func (l *Logger) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+
+	for i := maxBackups - 1; i > 0; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+	}
+	// reopen even if the rename failed so we keep logging somewhere
+	renameErr := os.Rename(l.path, l.path+".1")
+	if err := l.open(); err != nil {
+		return err
+	}
+	return renameErr
+}
@@ -1,14 +1,52 @@
 package message
 
-type ErrMsg struct{ Err error }
+import tea "github.com/charmbracelet/bubbletea"
+
+// ErrMsg is shown as a toast in the status bar, Retry is offered to the user
+// when it's set. Only a Fatal error takes over the whole screen.
+type ErrMsg struct {
+	Err   error
+	Retry tea.Cmd
+	Fatal bool
+}
 
 // For messages that contain errors it's often handy to also implement the
 // error interface on the message.
 func (e ErrMsg) Error() string { return e.Err.Error() }
 
+func (e ErrMsg) Unwrap() error { return e.Err }
+
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// NotifyMsg shows a short lived notification in the status bar.
+type NotifyMsg struct {
+	Severity Severity
+	Text     string
+}
+
 // Menubar Message
 type BackToMenubarMsg struct{}
 
+// ThemeMsg is sent to every page after the theme has been switched.
+type ThemeMsg struct{}
+
 // Rank Page Message
 // type BackToRankMsg struct{}
 
@@ -22,3 +60,27 @@ type DetailMsg struct {
 func (d *DetailMsg) AnimeID() int {
 	return d.ID
 }
+
+// Bookmarks Page Message
+// BookmarkMsg is sent whenever a bookmark is added or removed outside the bookmarks page.
+type BookmarkMsg struct {
+	ID         int
+	Bookmarked bool
+}
+
+// Compare Page Message
+// CompareMsg marks the anime for comparison, or unmarks it if it already is.
+type CompareMsg struct {
+	ID int
+}
+
+// Manga Detail Page Message
+type MangaDetailMsg struct {
+	ID int
+}
+
+// Franchise Page Message
+// FranchiseMsg opens the franchise of the anime, walking its relations.
+type FranchiseMsg struct {
+	ID int
+}
@@ -0,0 +1,263 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/bookmark"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+type editField int
+
+const (
+	editNone editField = iota
+	editNote
+	editTags
+)
+
+type Bookmarks struct {
+	*common
+
+	bookmarks []bookmark.Bookmark
+	table     *table.Model
+	input     textinput.Model
+	editing   editField
+	keys      BookmarksKeyMap
+	inputKeys InputKeyMap
+}
+
+func NewBookmarks(c *common) *Bookmarks {
+	columns := []table.Column{
+		{Title: "Title", Width: 40},
+		{Title: "Tags", Width: 20},
+		{Title: "Note", Width: 30},
+		{Title: "Added", Width: 10},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithHeight(10),
+		table.WithKeyMap(c.keyMaps.Bookmarks.KeyMap),
+	)
+
+	ti := textinput.New()
+	ti.CharLimit = 256
+
+	b := &Bookmarks{
+		common:    c,
+		table:     &t,
+		input:     ti,
+		keys:      c.keyMaps.Bookmarks,
+		inputKeys: c.keyMaps.Input,
+	}
+	b.applyTheme()
+	b.refresh()
+	return b
+}
+
+// applyTheme restyles the components that keep a copy of their styles.
+func (b *Bookmarks) applyTheme() {
+	b.table.SetStyles(table.Styles{
+		Header:   b.theme.TableHeader,
+		Cell:     b.theme.TableCell,
+		Selected: b.theme.TableSelected,
+	})
+}
+
+func (b Bookmarks) Init() tea.Cmd { return nil }
+
+func (b *Bookmarks) Focus() { b.table.Focus() }
+func (b *Bookmarks) Blur()  { b.table.Blur() }
+
+// Editing reports whether the page is capturing key presses for the note or tags input.
+func (b *Bookmarks) Editing() bool { return b.editing != editNone }
+
+func (b *Bookmarks) KeyMap() help.KeyMap {
+	if b.Editing() {
+		return b.inputKeys
+	}
+	return b.keys
+}
+
+// refresh reloads the rows from the store.
+func (b *Bookmarks) refresh() {
+	b.bookmarks = b.store.List()
+
+	rows := make([]table.Row, len(b.bookmarks))
+	for i, v := range b.bookmarks {
+		rows[i] = table.Row{v.Title, strings.Join(v.Tags, ", "), v.Note, v.CreatedAt.Format(time.DateOnly)}
+	}
+	b.table.SetRows(rows)
+}
+
+func (b *Bookmarks) selected() (bookmark.Bookmark, bool) {
+	i := b.table.Cursor()
+	if i < 0 || i >= len(b.bookmarks) {
+		return bookmark.Bookmark{}, false
+	}
+	return b.bookmarks[i], true
+}
+
+func (b *Bookmarks) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.table.SetWidth(msg.Width)
+		// leave one line for the note and tags input
+		b.table.SetHeight(msg.Height - 1)
+		b.input.Width = msg.Width - 10
+		return b, nil
+
+	case message.BookmarkMsg:
+		b.refresh()
+		return b, nil
+
+	case message.ThemeMsg:
+		b.applyTheme()
+		return b, nil
+
+	case tea.KeyMsg:
+		if b.Editing() {
+			return b.updateInput(msg)
+		}
+
+		if !b.table.Focused() {
+			return b, nil
+		}
+
+		selected, ok := b.selected()
+		switch {
+		case key.Matches(msg, b.keys.Open):
+			if !ok {
+				return b, nil
+			}
+			return b, func() tea.Msg { return message.DetailMsg{ID: selected.ID} }
+
+		case key.Matches(msg, b.keys.Remove):
+			if !ok {
+				return b, nil
+			}
+			if err := b.store.Remove(selected.ID); err != nil {
+				return b, func() tea.Msg { return message.ErrMsg{Err: err} }
+			}
+			b.refresh()
+			return b, nil
+
+		case key.Matches(msg, b.keys.Note):
+			if !ok {
+				return b, nil
+			}
+			b.editing = editNote
+			b.input.Prompt = "Note: "
+			b.input.SetValue(selected.Note)
+			return b, b.input.Focus()
+
+		case key.Matches(msg, b.keys.Tags):
+			if !ok {
+				return b, nil
+			}
+			b.editing = editTags
+			b.input.Prompt = "Tags: "
+			b.input.SetValue(strings.Join(selected.Tags, ", "))
+			return b, b.input.Focus()
+		}
+	}
+
+	*b.table, cmd = b.table.Update(msg)
+	return b, cmd
+}
+
+func (b *Bookmarks) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, b.inputKeys.Cancel):
+		b.editing = editNone
+		b.input.Blur()
+		return b, nil
+
+	case key.Matches(msg, b.inputKeys.Accept):
+		field := b.editing
+		b.editing = editNone
+		b.input.Blur()
+
+		selected, ok := b.selected()
+		if !ok {
+			return b, nil
+		}
+
+		var err error
+		switch field {
+		case editNote:
+			err = b.store.SetNote(selected.ID, strings.TrimSpace(b.input.Value()))
+		case editTags:
+			err = b.store.SetTags(selected.ID, splitTags(b.input.Value()))
+		}
+		if err != nil {
+			return b, func() tea.Msg { return message.ErrMsg{Err: fmt.Errorf("failed to update bookmark: %w", err)} }
+		}
+		b.refresh()
+		return b, nil
+	}
+
+	var cmd tea.Cmd
+	b.input, cmd = b.input.Update(msg)
+	return b, cmd
+}
+
+func splitTags(s string) []string {
+	var tags []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			tags = append(tags, v)
+		}
+	}
+	return tags
+}
+
+func (b Bookmarks) View() string {
+	if len(b.bookmarks) == 0 {
+		return lipgloss.NewStyle().
+			Width(b.table.Width()).
+			Height(b.table.Height()).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render("No bookmarks yet, bookmark an anime from the Rank, Search or Detail page.")
+	}
+
+	var input string
+	if b.Editing() {
+		input = b.input.View()
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		b.theme.Frame.Align(lipgloss.Left).Render(b.table.View()),
+		input,
+	)
+}
+
+// toggleBookmark stars or unstars the anime and lets the other pages know about it.
+func toggleBookmark(s *bookmark.Store, id int, title string) tea.Cmd {
+	return func() tea.Msg {
+		bookmarked, err := s.Toggle(id, title)
+		if err != nil {
+			return message.ErrMsg{Err: fmt.Errorf("failed to bookmark %s: %w", title, err)}
+		}
+		return message.BookmarkMsg{ID: id, Bookmarked: bookmarked}
+	}
+}
+
+// animeTitle prefers the english title and falls back to the romaji one.
+func animeTitle(a entity.Anime) string {
+	if a.AlternativeTitle.EngTitle != "" {
+		return a.AlternativeTitle.EngTitle
+	}
+	return a.Title
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// barEighths are the partial blocks used to draw the end of a bar,
+// so bars keep their proportions on narrow terminals.
+var barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+type bar struct {
+	label string
+	value int
+}
+
+// barChart draws one horizontal bar per entry, scaled so the largest value
+// spans whatever width is left after the labels and the counts.
+func barChart(bars []bar, width int, barStyle lipgloss.Style) string {
+	labelWidth, countWidth, maxValue := 0, 0, 0
+	for _, b := range bars {
+		labelWidth = max(labelWidth, lipgloss.Width(b.label))
+		countWidth = max(countWidth, len(humanizeCount(b.value)))
+		maxValue = max(maxValue, b.value)
+	}
+
+	// a space on each side of the bar
+	barWidth := max(1, width-labelWidth-countWidth-2)
+
+	lines := make([]string, len(bars))
+	for i, b := range bars {
+		eighths := 0
+		if maxValue > 0 {
+			eighths = b.value * barWidth * 8 / maxValue
+		}
+		drawn := strings.Repeat("█", eighths/8) + barEighths[eighths%8]
+		pad := strings.Repeat(" ", max(0, barWidth-lipgloss.Width(drawn)))
+
+		lines[i] = fmt.Sprintf("%-*s %s%s %*s",
+			labelWidth, b.label,
+			barStyle.Render(drawn), pad,
+			countWidth, humanizeCount(b.value),
+		)
+	}
+	return strings.Join(lines, "\n")
+}
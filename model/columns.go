@@ -0,0 +1,154 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/izzanzahrial/tui/entity"
+)
+
+// cellPadding is the horizontal padding the table adds around every cell.
+const cellPadding = 2
+
+type rankColumn struct {
+	title string
+	// weight is the share of the spare width the column gets
+	weight int
+	// minWidth is the width below which the column is hidden instead of squeezed
+	minWidth int
+	// priority decides which columns are hidden first on narrow screens, higher goes first
+	priority int
+	// width is the final width decided by layoutColumns
+	width  int
+	sortBy sortField
+	value  func(entity.AnimeRank) string
+}
+
+var rankColumns = map[string]rankColumn{
+	"rank": {
+		title: "Rank", minWidth: 4, priority: 0, sortBy: sortRank,
+		value: func(a entity.AnimeRank) string { return strconv.Itoa(a.Rank.Rank) },
+	},
+	"title": {
+		title: "Title", weight: 4, minWidth: 20, priority: 0, sortBy: sortTitle,
+		value: func(a entity.AnimeRank) string { return animeTitle(a.Anime) },
+	},
+	"japanese_title": {
+		title: "Japanese Title", weight: 3, minWidth: 20, priority: 4, sortBy: sortJapaneseTitle,
+		value: func(a entity.AnimeRank) string { return a.Anime.AlternativeTitle.JpnTitle },
+	},
+	"score": {
+		title: "Score", minWidth: 5, priority: 1, sortBy: sortScore,
+		value: func(a entity.AnimeRank) string {
+			if a.Anime.Mean == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.2f", a.Anime.Mean)
+		},
+	},
+	"popularity": {
+		title: "Popularity", minWidth: 10, priority: 3, sortBy: sortPopularity,
+		value: func(a entity.AnimeRank) string { return "#" + strconv.Itoa(a.Anime.Popularity) },
+	},
+	"members": {
+		title: "Members", minWidth: 9, priority: 2, sortBy: sortMembers,
+		value: func(a entity.AnimeRank) string { return humanizeCount(a.Anime.Members) },
+	},
+	"episodes": {
+		title: "Eps", minWidth: 4, priority: 2, sortBy: sortEpisodes,
+		value: func(a entity.AnimeRank) string {
+			if a.Anime.Episodes == 0 {
+				return "?"
+			}
+			return strconv.Itoa(a.Anime.Episodes)
+		},
+	},
+	"season": {
+		title: "Season", minWidth: 11, priority: 3, sortBy: sortSeason,
+		value: func(a entity.AnimeRank) string {
+			if a.Anime.StartSeason.Year == 0 {
+				return ""
+			}
+			return fmt.Sprintf("%s %d", a.Anime.StartSeason.Season, a.Anime.StartSeason.Year)
+		},
+	},
+	"media_type": {
+		title: "Type", minWidth: 7, priority: 3, sortBy: sortMediaType,
+		value: func(a entity.AnimeRank) string { return a.Anime.MediaType.String() },
+	},
+}
+
+// newRankColumns picks the configured columns, unknown names are skipped
+// and the default set is used when nothing valid is left.
+func newRankColumns(names []string) []rankColumn {
+	var cols []rankColumn
+	for _, name := range names {
+		if c, ok := rankColumns[name]; ok {
+			cols = append(cols, c)
+		}
+	}
+	if len(cols) == 0 {
+		for _, name := range []string{"rank", "title", "japanese_title"} {
+			cols = append(cols, rankColumns[name])
+		}
+	}
+	return cols
+}
+
+// layoutColumns hides the lowest priority columns until the rest fit in width
+// and then hands out the spare width proportionally to their weight.
+func layoutColumns(cols []rankColumn, width int) []rankColumn {
+	visible := append([]rankColumn(nil), cols...)
+
+	required := func() int {
+		total := 0
+		for _, c := range visible {
+			total += c.minWidth + cellPadding
+		}
+		return total
+	}
+
+	for len(visible) > 1 && required() > width {
+		drop := 0
+		for i, c := range visible {
+			if c.priority >= visible[drop].priority {
+				drop = i
+			}
+		}
+		visible = append(visible[:drop], visible[drop+1:]...)
+	}
+
+	spare := max(0, width-required())
+	totalWeight := 0
+	for _, c := range visible {
+		totalWeight += c.weight
+	}
+
+	for i, c := range visible {
+		visible[i].width = c.minWidth
+		if totalWeight > 0 {
+			visible[i].width += spare * c.weight / totalWeight
+		}
+	}
+	return visible
+}
+
+func tableColumns(cols []rankColumn) []table.Column {
+	columns := make([]table.Column, len(cols))
+	for i, c := range cols {
+		columns[i] = table.Column{Title: c.title, Width: c.width}
+	}
+	return columns
+}
+
+func humanizeCount(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fK", float64(n)/1_000)
+	default:
+		return strconv.Itoa(n)
+	}
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"io"
+
+	"github.com/izzanzahrial/tui/bookmark"
+	"github.com/izzanzahrial/tui/logger"
+	"github.com/izzanzahrial/tui/style"
+	"github.com/izzanzahrial/tui/url"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// common holds what every page shares, Main creates it once and hands the
+// same pointer to every page so e.g. switching the theme reaches all of them.
+type common struct {
+	client  *url.Client
+	store   *bookmark.Store
+	log     *logger.Logger
+	zone    *zone.Manager
+	keyMaps KeyMaps
+	theme   *style.Theme
+	// clipboard is the terminal OSC 52 sequences are written to
+	clipboard io.Writer
+	// getenv reads the environment of the user's terminal
+	getenv func(string) string
+	// remote is set when the user is on the other end of an SSH session,
+	// see WithRemote
+	remote bool
+}
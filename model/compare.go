@@ -0,0 +1,325 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+const (
+	compareLabelWidth = 12
+	// columns narrower than this scroll horizontally instead of squeezing
+	compareMinColumnWidth = 18
+)
+
+type compareLoadedMsg struct {
+	id     int
+	detail *entity.Detail
+}
+
+// better reports whether a beats b within a row.
+type better func(a, b float64) bool
+
+var (
+	higher better = func(a, b float64) bool { return a > b }
+	lower  better = func(a, b float64) bool { return a < b }
+)
+
+// Compare lays the marked anime side by side, one column each.
+type Compare struct {
+	*common
+
+	viewport  viewport.Model
+	ids       []int
+	details   map[int]*entity.Detail
+	isFocused bool
+	keys      CompareKeyMap
+}
+
+func NewCompare(c *common) *Compare {
+	vp := viewport.New(0, 0)
+	vp.KeyMap = c.keyMaps.Compare.KeyMap
+
+	return &Compare{
+		common:   c,
+		viewport: vp,
+		details:  make(map[int]*entity.Detail),
+		keys:     c.keyMaps.Compare,
+	}
+}
+
+func (c Compare) Init() tea.Cmd { return nil }
+
+func (c *Compare) Focus() { c.isFocused = true }
+func (c *Compare) Blur()  { c.isFocused = false }
+
+// Len is the number of marked anime.
+func (c *Compare) Len() int { return len(c.ids) }
+
+// Toggle marks the anime, or unmarks it if it already is, and reports
+// whether it's marked now.
+func (c *Compare) Toggle(id int) (bool, tea.Cmd) {
+	if i := slices.Index(c.ids, id); i >= 0 {
+		c.remove(i)
+		return false, nil
+	}
+
+	c.ids = append(c.ids, id)
+	c.render()
+	return true, c.load(id)
+}
+
+// load fetches the detail, usually from the client's cache.
+func (c *Compare) load(id int) tea.Cmd {
+	client := c.client
+	var load tea.Cmd
+	load = func() tea.Msg {
+		detail, err := client.AnimeDetail(id)
+		if err != nil {
+			return message.ErrMsg{
+				Err:   fmt.Errorf("failed to get detail for ID %d: %w", id, err),
+				Retry: load,
+			}
+		}
+		return compareLoadedMsg{id: id, detail: detail}
+	}
+	return load
+}
+
+func (c *Compare) remove(i int) {
+	delete(c.details, c.ids[i])
+	c.ids = slices.Delete(c.ids, i, i+1)
+	c.render()
+}
+
+func (c *Compare) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.viewport.Width = msg.Width
+		c.viewport.Height = msg.Height
+		c.render()
+		return c, nil
+
+	case compareLoadedMsg:
+		if slices.Contains(c.ids, msg.id) {
+			c.details[msg.id] = msg.detail
+			c.render()
+		}
+		return c, nil
+
+	case message.ThemeMsg:
+		c.render()
+		return c, nil
+
+	case tea.KeyMsg:
+		if !c.isFocused {
+			return c, nil
+		}
+
+		switch {
+		case key.Matches(msg, c.keys.Remove):
+			n, err := strconv.Atoi(msg.String())
+			if err == nil && n > 0 && n <= len(c.ids) {
+				c.remove(n - 1)
+			}
+			return c, nil
+		case key.Matches(msg, c.keys.Clear):
+			c.ids = nil
+			clear(c.details)
+			c.render()
+			return c, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	c.viewport, cmd = c.viewport.Update(msg)
+	return c, cmd
+}
+
+// render lays out one row per attribute. Within a row the best value is
+// highlighted, values every anime shares are muted.
+func (c *Compare) render() {
+	if len(c.ids) == 0 {
+		c.viewport.SetContent("")
+		return
+	}
+
+	colWidth := max(compareMinColumnWidth, (c.viewport.Width-compareLabelWidth)/len(c.ids))
+	cell := lipgloss.NewStyle().Width(colWidth).PaddingRight(1)
+	label := c.theme.Muted.Width(compareLabelWidth)
+
+	// the anime still loading are left out of the comparison
+	var loaded []*entity.Detail
+	for _, id := range c.ids {
+		if d, ok := c.details[id]; ok {
+			loaded = append(loaded, d)
+		}
+	}
+
+	row := func(name string, values func(d *entity.Detail) string) string {
+		cells := []string{label.Render(name)}
+		for _, id := range c.ids {
+			if d, ok := c.details[id]; ok {
+				cells = append(cells, cell.Render(values(d)))
+			} else {
+				cells = append(cells, cell.Render(c.theme.Muted.Render("Loading...")))
+			}
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+	}
+
+	// numbers are highlighted when they're the best of the row,
+	// zero means MAL doesn't know and never wins
+	number := func(name string, value func(d *entity.Detail) float64, format func(d *entity.Detail) string, wins better) string {
+		best := 0.0
+		for _, d := range loaded {
+			if v := value(d); v != 0 && (best == 0 || wins(v, best)) {
+				best = v
+			}
+		}
+		same := allEqual(loaded, func(d *entity.Detail) string { return format(d) })
+		return row(name, func(d *entity.Detail) string {
+			switch {
+			case same:
+				return c.theme.Muted.Render(format(d))
+			case value(d) != 0 && value(d) == best:
+				return c.theme.Highlight.Render(format(d))
+			}
+			return format(d)
+		})
+	}
+
+	// text is highlighted when it differs from the rest
+	text := func(name string, value func(d *entity.Detail) string) string {
+		same := allEqual(loaded, value)
+		return row(name, func(d *entity.Detail) string {
+			if same {
+				return c.theme.Muted.Render(value(d))
+			}
+			return c.theme.Highlight.Render(value(d))
+		})
+	}
+
+	// list items every anime has are muted, the rest are what tells them apart
+	list := func(name string, values func(d *entity.Detail) []string) string {
+		return row(name, func(d *entity.Detail) string {
+			var items []string
+			for _, v := range values(d) {
+				shared := true
+				for _, other := range loaded {
+					shared = shared && slices.Contains(values(other), v)
+				}
+				if shared {
+					items = append(items, c.theme.Muted.Render(v))
+				} else {
+					items = append(items, c.theme.Highlight.Render(v))
+				}
+			}
+			if len(items) == 0 {
+				return "-"
+			}
+			return strings.Join(items, ", ")
+		})
+	}
+
+	titles := []string{label.Render("")}
+	for i, id := range c.ids {
+		title := strconv.Itoa(id)
+		if d, ok := c.details[id]; ok {
+			title = d.AlternativeTitle.EngTitle
+			if title == "" {
+				title = d.Title
+			}
+		}
+		titles = append(titles, cell.Render(c.theme.DetailTitle.Render(fmt.Sprintf("%d %s", i+1, title))))
+	}
+
+	rows := []string{
+		lipgloss.JoinHorizontal(lipgloss.Top, titles...),
+		c.theme.Separator.Render(strings.Repeat("─", compareLabelWidth+colWidth*len(c.ids))),
+		number("Score", func(d *entity.Detail) float64 { return d.Mean }, func(d *entity.Detail) string {
+			if d.Mean == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.2f", d.Mean)
+		}, higher),
+		number("Rank", func(d *entity.Detail) float64 { return float64(d.Rank) }, func(d *entity.Detail) string {
+			if d.Rank == 0 {
+				return "-"
+			}
+			return "#" + strconv.Itoa(d.Rank)
+		}, lower),
+		number("Popularity", func(d *entity.Detail) float64 { return float64(d.Popularity) }, func(d *entity.Detail) string {
+			return "#" + strconv.Itoa(d.Popularity)
+		}, lower),
+		number("Members", func(d *entity.Detail) float64 { return float64(d.Members) }, func(d *entity.Detail) string {
+			return humanizeCount(d.Members)
+		}, higher),
+		// more episodes isn't better, only different
+		text("Episodes", func(d *entity.Detail) string {
+			if d.Episodes == 0 {
+				return "?"
+			}
+			return strconv.Itoa(d.Episodes)
+		}),
+		text("Type", func(d *entity.Detail) string { return d.MediaType.String() }),
+		text("Season", func(d *entity.Detail) string {
+			if d.StartSeason.Year == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%s %d", d.StartSeason.Season, d.StartSeason.Year)
+		}),
+		text("Status", func(d *entity.Detail) string {
+			if d.Status == "" {
+				return "-"
+			}
+			return d.Status.String()
+		}),
+		list("Studios", func(d *entity.Detail) []string { return names(d.Studios) }),
+		list("Genres", func(d *entity.Detail) []string { return names(d.Genres) }),
+	}
+
+	c.viewport.SetContent(strings.Join(rows, "\n"))
+}
+
+// allEqual reports whether value is the same for every detail,
+// which is trivially true while fewer than two are loaded.
+func allEqual(details []*entity.Detail, value func(d *entity.Detail) string) bool {
+	for _, d := range details[min(1, len(details)):] {
+		if value(d) != value(details[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func names[T interface{ GetName() string }](items []T) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.GetName()
+	}
+	return out
+}
+
+func (c Compare) View() string {
+	if len(c.ids) < 2 {
+		hint := fmt.Sprintf("Press %s on Rank or Detail to mark anime to compare.", c.keyMaps.Rank.Compare.Help().Key)
+		if len(c.ids) == 1 {
+			hint = "Mark one more anime to compare."
+		}
+		return lipgloss.NewStyle().
+			Width(c.viewport.Width).
+			Height(c.viewport.Height).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(hint)
+	}
+	return c.viewport.View()
+}
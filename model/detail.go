@@ -3,95 +3,78 @@ package model
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/izzanzahrial/tui/entity"
 	"github.com/izzanzahrial/tui/message"
-	"github.com/izzanzahrial/tui/style"
 	"github.com/izzanzahrial/tui/url"
+	"github.com/izzanzahrial/tui/xdg"
 )
 
-// TODO: handle when certain data is zero
-// e.g. data background of 'To Be Hero X' ID '53447'
-// even though already using omitzero within the entity.Detail json tag
-// within the template still show empty string
-const animeTemplate = `{{.Title}}
-> Released: {{.StartDate}} | Status: {{.Status}}
-
-## Overview
-Rank: {{.Rank}} | Popularity: {{.Popularity}} | Rating: {{.Rating}}
-Genres: {{.Genres}}
-Studios: {{.Studios}}
-{{.Separator}}
-{{if .Synopsis}}
-## Synopsis
-
-{{.Synopsis}}
-{{end}}
-{{if .Background}}
-{{.Separator}}
-## Background
-
-{{.Background}}
-{{end}}
-{{if .RelatedAnimes}}
-{{.Separator}}
-## Related Anime
-{{range .RelatedAnimes}}
-- {{.Node.Title}} ({{.RelationType}})
-{{end}}
-{{end}}
-{{if .Recomendations}}
-{{.Separator}}
-## Recommendations
-{{range .Recomendations}}
-- {{.Node.Title}}
-{{end}}
-{{end}}`
-
-type templateData struct {
-	Title          string
-	StartDate      string
-	Status         string
-	Rank           int
-	Popularity     int
-	Rating         string
-	Genres         string
-	Studios        string
-	Synopsis       string
-	Background     string
-	RelatedAnimes  []entity.RelatedAnime
-	Recomendations []entity.Recommendation
-	Separator      string
-}
+// detailView is the sub-view the Detail page shows.
+type detailView int
+
+const (
+	detailOverview detailView = iota
+	detailStatistics
+	detailPictures
+)
 
 type Detail struct {
+	*common
+
 	viewport  viewport.Model
-	client    *url.Client
-	templ     *template.Template
+	current   *entity.Detail
 	ready     bool
 	isFocused bool
+	view      detailView
+	keys      DetailKeyMap
+
+	// the pictures of the current anime, by link, as they're downloaded
+	picture  int
+	pictures map[string]*picture
+
+	// templ is the user's layout when they have one, see detail.tmpl
+	templ        *template.Template
+	defaultTempl *template.Template
+	templFuncs   template.FuncMap
+	templFile    string
+	templMod     time.Time
+	markdown     markdownRenderer
 }
 
-func NewDetail(c *url.Client) *Detail {
-	templ, err := template.New("anime_detail").Parse(animeTemplate)
-	if err != nil {
-		panic(err)
-	}
+func NewDetail(c *common) *Detail {
+	vp := viewport.New(0, 0)
+	vp.KeyMap = c.keyMaps.Detail.KeyMap
 
-	return &Detail{
-		viewport: viewport.New(0, 0),
-		client:   c,
-		templ:    templ,
-		ready:    false,
+	d := &Detail{
+		common:    c,
+		viewport:  vp,
+		ready:     false,
+		keys:      c.keyMaps.Detail,
+		pictures:  make(map[string]*picture),
+		templFile: DetailTemplateFile(),
 	}
+	d.templFuncs = detailTemplateFuncs(c, func() int { return d.viewport.Width }, &d.markdown)
+	// parsed once already, only the functions are this page's
+	d.defaultTempl = template.Must(defaultTemplate.Clone()).Funcs(d.templFuncs)
+	d.templ = d.defaultTempl
+	return d
 }
 
-func (d Detail) Init() tea.Cmd { return nil }
+// Init loads the user's template, if any, and keeps watching it.
+func (d Detail) Init() tea.Cmd { return d.checkTemplate(0) }
 
 func (d *Detail) Focus() { d.isFocused = true }
 func (d *Detail) Blur()  { d.isFocused = false }
@@ -112,73 +95,404 @@ func (d *Detail) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// here.
 
 			d.viewport = viewport.New(msg.Width, msg.Height-verticalMarginHeight)
+			d.viewport.KeyMap = d.keys.KeyMap
 			d.viewport.YPosition = headerHeight
 			d.ready = true
 		} else {
 			d.viewport.Width = msg.Width
 			d.viewport.Height = msg.Height - verticalMarginHeight
 		}
+		// wrapping and charts depend on the width
+		return d, d.refresh()
+
+	case tea.MouseMsg:
+		if d.isFocused && leftClick(msg) {
+			if cmd := d.click(msg); cmd != nil {
+				return d, cmd
+			}
+		}
 
 	case tea.KeyMsg:
 		if !d.isFocused {
 			return d, nil
 		}
 
+		switch {
+		case key.Matches(msg, d.keys.Bookmark):
+			if d.current == nil {
+				return d, nil
+			}
+			title := d.current.AlternativeTitle.EngTitle
+			if title == "" {
+				title = d.current.Title
+			}
+			return d, toggleBookmark(d.store, d.current.ID, title)
+		case key.Matches(msg, d.keys.Compare):
+			if d.current == nil {
+				return d, nil
+			}
+			id := d.current.ID
+			return d, func() tea.Msg { return message.CompareMsg{ID: id} }
+		case key.Matches(msg, d.keys.Franchise):
+			if d.current == nil {
+				return d, nil
+			}
+			id := d.current.ID
+			return d, func() tea.Msg { return message.FranchiseMsg{ID: id} }
+		case key.Matches(msg, d.keys.Browser, d.keys.CopyLink, d.keys.CopyTitle, d.keys.CopySummary):
+			if d.current == nil {
+				return d, nil
+			}
+			c := d.current
+			title := c.AlternativeTitle.EngTitle
+			if title == "" {
+				title = c.Title
+			}
+			switch {
+			case key.Matches(msg, d.keys.Browser):
+				return d, d.openInBrowser(c.ID)
+			case key.Matches(msg, d.keys.CopyLink):
+				return d, d.copyToClipboard(url.AnimePage(c.ID), "link")
+			case key.Matches(msg, d.keys.CopyTitle):
+				return d, d.copyToClipboard(title, "title")
+			}
+			summary := animeSummary(c.ID, title, c.StartSeason, c.MediaType, c.Episodes, c.Mean, c.Rank)
+			return d, d.copyToClipboard(summary, "summary")
+		case key.Matches(msg, d.keys.Statistics):
+			return d, d.switchView(detailStatistics)
+		case key.Matches(msg, d.keys.Pictures):
+			return d, d.switchView(detailPictures)
+		case d.view == detailPictures && key.Matches(msg, d.keys.NextPicture, d.keys.PrevPicture):
+			if d.current == nil || len(d.current.Pictures) == 0 {
+				return d, nil
+			}
+			n := len(d.current.Pictures)
+			if key.Matches(msg, d.keys.NextPicture) {
+				d.picture = (d.picture + 1) % n
+			} else {
+				d.picture = (d.picture - 1 + n) % n
+			}
+			return d, tea.Batch(d.refresh(), d.loadPicture())
+		case d.view == detailPictures && key.Matches(msg, d.keys.SavePicture):
+			return d, d.savePicture()
+		case d.view == detailPictures && key.Matches(msg, d.keys.OpenPicture):
+			return d, d.openPicture()
+		}
+
+	case pictureMsg:
+		d.pictures[msg.link] = decodePicture(msg)
+		return d, d.refresh()
+
+	case message.ThemeMsg:
+		return d, d.refresh()
+
+	case templateFileMsg:
+		next := d.checkTemplate(templateCheckInterval)
+		if msg.modTime.Equal(d.templMod) {
+			return d, next
+		}
+		d.templMod = msg.modTime
+		if err := d.loadTemplate(); err != nil {
+			return d, tea.Batch(next, func() tea.Msg { return message.ErrMsg{Err: err} })
+		}
+		return d, tea.Batch(next, d.refresh())
+
 	case message.DetailMsg:
 		d.viewport.GotoTop()
 		detail, err := d.client.AnimeDetail(msg.ID)
 		if err != nil {
 			return d, func() tea.Msg {
-				return message.ErrMsg{Err: fmt.Errorf("failed to get detail for ID %d: %w", msg.ID, err)}
+				return message.ErrMsg{
+					Err:   fmt.Errorf("failed to get detail for ID %d: %w", msg.ID, err),
+					Retry: func() tea.Msg { return msg },
+				}
 			}
 		}
 
-		content, err := d.renderContent(detail)
-		if err != nil {
-			return d, func() tea.Msg { return message.ErrMsg{Err: err} }
-		}
-		d.viewport.SetContent(content)
+		d.current = detail
+		d.picture = 0
+		clear(d.pictures)
+		return d, tea.Batch(d.refresh(), d.loadPicture())
 	}
 
 	d.viewport, cmd = d.viewport.Update(msg)
 	return d, cmd
 }
 
-// It handles text wrapping and templating in one step, entirely in memory.
-func (d *Detail) renderContent(data *entity.Detail) (string, error) {
-	// This style will handle word wrapping for us automatically.
-	// We subtract a little padding to make it look nice.
-	contentWidth := d.viewport.Width - 2
-	contentStyle := lipgloss.NewStyle().Width(contentWidth)
+// click opens the related or recommended anime, or related manga, under the mouse, if any.
+func (d *Detail) click(msg tea.MouseMsg) tea.Cmd {
+	if d.current == nil {
+		return nil
+	}
+
+	var nodes []entity.Node
+	for _, v := range d.current.RelatedAnimes {
+		nodes = append(nodes, v.Node)
+	}
+	for _, v := range d.current.Recomendations {
+		nodes = append(nodes, v.Node)
+	}
 
+	for _, n := range nodes {
+		if d.zone.Get(zoneAnimeLink(n.ID)).InBounds(msg) {
+			return func() tea.Msg { return message.DetailMsg{ID: n.ID} }
+		}
+	}
+	for _, v := range d.current.RelatedMangas {
+		if d.zone.Get(zoneMangaLink(v.Node.ID)).InBounds(msg) {
+			id := v.Node.ID
+			return func() tea.Msg { return message.MangaDetailMsg{ID: id} }
+		}
+	}
+	return nil
+}
+
+// switchView shows the sub-view, or goes back to the overview if it's
+// already shown.
+func (d *Detail) switchView(v detailView) tea.Cmd {
+	if d.view == v {
+		v = detailOverview
+	}
+	d.view = v
+	d.viewport.GotoTop()
+	return tea.Batch(d.refresh(), d.loadPicture())
+}
+
+// KeyMap returns the detail bindings, the picture ones come first in the
+// pictures view.
+func (d *Detail) KeyMap() help.KeyMap {
+	if d.view == detailPictures {
+		return detailPicturesHelp{d.keys}
+	}
+	return d.keys
+}
+
+// refresh renders the current anime again, e.g. after a resize or a theme change.
+func (d *Detail) refresh() tea.Cmd {
+	if d.current == nil {
+		return nil
+	}
+	switch d.view {
+	case detailStatistics:
+		d.viewport.SetContent(d.renderStatistics(d.current))
+		return nil
+	case detailPictures:
+		d.viewport.SetContent(d.renderPictures(d.current))
+		return nil
+	}
+
+	content, err := d.renderOverview(d.current, d.templ)
+	if err == nil {
+		d.viewport.SetContent(content)
+		return nil
+	}
+
+	var errs []error
+	if d.templ != d.defaultTempl {
+		// the user's template is dropped until they save it again
+		errs = append(errs, fmt.Errorf("%s: %w, showing the default layout until it's fixed", d.templFile, err))
+		d.templ = d.defaultTempl
+		content, err = d.renderOverview(d.current, d.templ)
+	}
+	if err == nil {
+		d.viewport.SetContent(content)
+	} else {
+		// not worth quitting for, the page says what went wrong instead
+		errs = append(errs, err)
+		d.viewport.SetContent(d.theme.Muted.Render(err.Error()))
+	}
+
+	cmds := make([]tea.Cmd, len(errs))
+	for i, err := range errs {
+		cmds[i] = func() tea.Msg { return message.ErrMsg{Err: err} }
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderOverview executes the layout, which takes care of wrapping too.
+func (d *Detail) renderOverview(data *entity.Detail, templ *template.Template) (string, error) {
+	name := data.AlternativeTitle.EngTitle
+	if name == "" {
+		name = data.Title
+	}
+
+	var buf bytes.Buffer
+	err := templ.Execute(&buf, DetailTemplateData{
+		Detail:     data,
+		Name:       name,
+		Width:      d.viewport.Width,
+		Bookmarked: d.store.Has(data.ID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute the Detail template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// renderStatistics charts how many users have the anime in each list
+// and, when MAL sends it, how they scored it.
+func (d *Detail) renderStatistics(data *entity.Detail) string {
 	title := data.AlternativeTitle.EngTitle
 	if title == "" {
 		title = data.Title
 	}
+	width := d.viewport.Width - 2
+	separator := d.theme.Separator.Render(strings.Repeat("─", d.viewport.Width))
 
-	// Prepare data for the template
-	templatePayload := templateData{
-		Title:          style.DetailTitle.Render(title),
-		StartDate:      data.StartDate,
-		Status:         strings.ReplaceAll(data.Status, "_", " "),
-		Rank:           data.Rank,
-		Popularity:     data.Popularity,
-		Rating:         data.Rating,
-		Genres:         joinNames(data.Genres),
-		Studios:        joinNames(data.Studios),
-		Synopsis:       contentStyle.Render(data.Synopsis),
-		Background:     contentStyle.Render(data.Background),
-		RelatedAnimes:  data.RelatedAnimes,
-		Recomendations: data.Recomendations,
-		Separator:      style.Separator.Render(strings.Repeat("─", d.viewport.Width)),
+	status := data.Statistics.Status
+	sections := []string{
+		d.theme.DetailTitle.Render(title),
+		"",
+		d.markdown.heading(d.theme, width, "Status"),
+		fmt.Sprintf("%s users have it in their list", humanizeCount(data.Statistics.NumListUsers)),
+		"",
+		barChart([]bar{
+			{"Watching", int(status.Watching)},
+			{"Completed", int(status.Completed)},
+			{"On Hold", int(status.OnHold)},
+			{"Dropped", int(status.Dropped)},
+			{"Plan to Watch", int(status.PlanToWatch)},
+		}, width, d.theme.Bar),
+		separator,
+		d.markdown.heading(d.theme, width, "Scores"),
+		"",
 	}
 
-	var buf bytes.Buffer
-	if err := d.templ.Execute(&buf, templatePayload); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
+	if len(data.Statistics.Scores) == 0 {
+		sections = append(sections, d.theme.Muted.Render("MAL didn't include the score distribution."))
+	} else {
+		bars := make([]bar, 0, 10)
+		for score := 10; score >= 1; score-- {
+			bars = append(bars, bar{label: strconv.Itoa(score), value: int(data.Statistics.Scores[score])})
+		}
+		sections = append(sections, barChart(bars, width, d.theme.Bar))
 	}
 
-	return buf.String(), nil
+	return strings.Join(sections, "\n")
+}
+
+// currentPicture returns the link of the picture being shown, if any.
+func (d *Detail) currentPicture() (string, bool) {
+	if d.current == nil || d.picture >= len(d.current.Pictures) {
+		return "", false
+	}
+	return d.current.Pictures[d.picture].Link(), true
+}
+
+// loadPicture downloads the picture being shown, unless it already is,
+// a failed one is tried again.
+func (d *Detail) loadPicture() tea.Cmd {
+	link, ok := d.currentPicture()
+	if d.view != detailPictures || !ok {
+		return nil
+	}
+	if p, ok := d.pictures[link]; ok && (p.data != nil || p.err == nil) {
+		return nil
+	}
+	// a placeholder so it isn't asked for twice while it downloads
+	d.pictures[link] = &picture{}
+
+	client := d.client
+	return func() tea.Msg {
+		data, err := client.Picture(link)
+		return pictureMsg{link: link, data: data, err: err}
+	}
+}
+
+// renderPictures draws the picture being shown as large as the viewport
+// allows, below a line saying which one it is.
+func (d *Detail) renderPictures(data *entity.Detail) string {
+	if len(data.Pictures) == 0 {
+		return d.theme.Muted.Render("MAL has no pictures for this anime.")
+	}
+
+	link := data.Pictures[d.picture].Link()
+	caption := d.theme.Muted.Render(fmt.Sprintf("Picture %d of %d • %s", d.picture+1, len(data.Pictures), link))
+
+	var body string
+	p := d.pictures[link]
+	switch {
+	case p == nil || (p.data == nil && p.err == nil):
+		body = "Loading the picture..."
+	case p.err != nil:
+		body = p.err.Error()
+	case d.theme.NoColor:
+		body = fmt.Sprintf("Pictures can't be drawn without colors, press %s to open it instead.", d.keys.OpenPicture.Help().Key)
+	default:
+		body = halfBlocks(p.img, d.viewport.Width, d.viewport.Height-2, d.theme.Picture)
+	}
+
+	return caption + "\n\n" + lipgloss.PlaceHorizontal(d.viewport.Width, lipgloss.Center, body)
+}
+
+// savePicture writes the picture being shown to the pictures directory.
+func (d *Detail) savePicture() tea.Cmd {
+	if d.remote {
+		return func() tea.Msg {
+			return message.NotifyMsg{Severity: message.SeverityWarning, Text: "Pictures can't be saved over SSH"}
+		}
+	}
+	data, name, ok := d.downloadedPicture()
+	if !ok {
+		return nil
+	}
+
+	return func() tea.Msg {
+		dir := xdg.PicturesDir()
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return message.ErrMsg{Err: fmt.Errorf("failed to save the picture: %w", err)}
+		}
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return message.ErrMsg{Err: fmt.Errorf("failed to save the picture: %w", err)}
+		}
+		return message.NotifyMsg{Severity: message.SeverityInfo, Text: "Saved to " + file}
+	}
+}
+
+// openPicture hands the picture being shown to the system image viewer,
+// through a copy in the temporary directory.
+func (d *Detail) openPicture() tea.Cmd {
+	if d.remote {
+		return func() tea.Msg {
+			return message.NotifyMsg{Severity: message.SeverityWarning, Text: "Pictures can't be opened over SSH"}
+		}
+	}
+	data, name, ok := d.downloadedPicture()
+	if !ok {
+		return nil
+	}
+
+	return func() tea.Msg {
+		file := filepath.Join(os.TempDir(), xdg.AppName+"-"+name)
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return message.ErrMsg{Err: fmt.Errorf("failed to open the picture: %w", err)}
+		}
+		if err := xdg.Open(file); err != nil {
+			return message.ErrMsg{Err: fmt.Errorf("failed to open the picture: %w", err)}
+		}
+		return nil
+	}
+}
+
+// downloadedPicture returns the picture being shown and the file name it's
+// saved as, e.g. 52991-2.jpg, once it has been downloaded.
+func (d *Detail) downloadedPicture() ([]byte, string, bool) {
+	link, ok := d.currentPicture()
+	if !ok {
+		return nil, "", false
+	}
+	p := d.pictures[link]
+	if p == nil || p.data == nil {
+		return nil, "", false
+	}
+
+	ext := path.Ext(link)
+	if ext == "" {
+		ext = ".jpg"
+	}
+	return p.data, fmt.Sprintf("%d-%d%s", d.current.ID, d.picture+1, ext), true
 }
 
 func (d Detail) View() string {
@@ -197,12 +511,24 @@ func (d Detail) View() string {
 }
 
 func (d Detail) headerView() string {
-	line := strings.Repeat("─", d.viewport.Width)
-	return lipgloss.JoinHorizontal(lipgloss.Center, line)
+	var tabs string
+	for v, name := range []string{"Overview", "Statistics", "Pictures"} {
+		if detailView(v) == d.view {
+			tabs += d.theme.ActiveSub.Render(name)
+		} else {
+			tabs += d.theme.SubTab.Render(name)
+		}
+	}
+	line := strings.Repeat("─", max(0, d.viewport.Width-lipgloss.Width(tabs)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, tabs, line)
 }
 
 func (d Detail) footerView() string {
-	info := style.Info.Render(fmt.Sprintf("%3.f%%", d.viewport.ScrollPercent()*100))
+	percent := fmt.Sprintf("%3.f%%", d.viewport.ScrollPercent()*100)
+	if d.current != nil && d.store.Has(d.current.ID) {
+		percent = "★ " + percent
+	}
+	info := d.theme.Info.Render(percent)
 	line := strings.Repeat("─", max(0, d.viewport.Width-lipgloss.Width(info)))
 	return lipgloss.JoinHorizontal(lipgloss.Center, line, info)
 }
@@ -0,0 +1,151 @@
+package model
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/xdg"
+)
+
+// defaultDetailTemplate is the layout used unless the user has their own,
+// it documents what a template can use.
+//
+//go:embed detail.tmpl
+var defaultDetailTemplate string
+
+// defaultTemplate is parsed when the program starts, so a mistake in it
+// can't wait until an anime is shown. The functions it's parsed with are
+// only placeholders, every page binds its own.
+var defaultTemplate = template.Must(template.New("detail.tmpl").
+	Funcs(detailTemplateFuncs(nil, nil, nil)).
+	Parse(defaultDetailTemplate))
+
+// templateCheckInterval is how often the user's template is checked for
+// changes, it's only a stat.
+const templateCheckInterval = time.Second
+
+// DetailTemplateFile is where the user's Detail layout is read from.
+func DetailTemplateFile() string {
+	return filepath.Join(xdg.ConfigDir(), "detail.tmpl")
+}
+
+// DetailTemplateData is what the Detail template is executed with: every
+// decoded field of the anime and what the page knows about it.
+type DetailTemplateData struct {
+	*entity.Detail
+	// Name is the english title, or the title when there's none.
+	Name string
+	// Width is how many cells wide the page is.
+	Width int
+	// Bookmarked is whether the anime is in the bookmarks.
+	Bookmarked bool
+}
+
+// templateFileMsg is the state of the user's template file, modTime is
+// zero when there's none.
+type templateFileMsg struct {
+	modTime time.Time
+}
+
+// detailTemplateFuncs are the functions the template can use on top of the
+// standard ones, detail.tmpl lists them.
+func detailTemplateFuncs(c *common, width func() int, md *markdownRenderer) template.FuncMap {
+	return template.FuncMap{
+		"title":     func(s string) string { return c.theme.DetailTitle.Render(s) },
+		"muted":     func(s string) string { return c.theme.Muted.Render(s) },
+		"separator": func() string { return c.theme.Separator.Render(strings.Repeat("─", width())) },
+		// a little narrower than the page so it doesn't touch the edge
+		"wrap":     func(s string) string { return lipgloss.NewStyle().Width(width() - 2).Render(s) },
+		"markdown": func(s string) (string, error) { return md.render(c.theme, width()-2, s) },
+		"heading": func(s string) (string, error) {
+			return md.render(c.theme, width()-2, "## "+markdownEscaper.Replace(s))
+		},
+		"footnotes": footnotes,
+		"join":      joinAny,
+		"stars":     stars,
+		"humanize": func(n any) (string, error) {
+			v := reflect.ValueOf(n)
+			if !v.CanInt() {
+				return "", fmt.Errorf("humanize takes a count, not %T", n)
+			}
+			return humanizeCount(int(v.Int())), nil
+		},
+		// link makes the title clickable, see Detail.click
+		"link":      func(n entity.Node) string { return c.zone.Mark(zoneAnimeLink(n.ID), n.Title) },
+		"mangaLink": func(n entity.Node) string { return c.zone.Mark(zoneMangaLink(n.ID), n.Title) },
+	}
+}
+
+// joinAny joins a list of texts, or of anything with a name like genres
+// and studios, with sep.
+func joinAny(sep string, list any) (string, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice {
+		return "", fmt.Errorf("join takes a list, not %T", list)
+	}
+	parts := make([]string, 0, v.Len())
+	for i := range v.Len() {
+		switch item := v.Index(i).Interface().(type) {
+		case interface{ GetName() string }:
+			parts = append(parts, item.GetName())
+		default:
+			parts = append(parts, fmt.Sprint(item))
+		}
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// stars rounds a score out of 10 to five stars.
+func stars(score float64) string {
+	n := min(5, max(0, int(math.Round(score/2))))
+	return strings.Repeat("★", n) + strings.Repeat("☆", 5-n)
+}
+
+// checkTemplate stats the user's template after delay, so the page can
+// reload it when it changes.
+func (d *Detail) checkTemplate(delay time.Duration) tea.Cmd {
+	path := d.templFile
+	check := func(time.Time) tea.Msg {
+		info, err := os.Stat(path)
+		if err != nil {
+			return templateFileMsg{}
+		}
+		return templateFileMsg{modTime: info.ModTime()}
+	}
+	if delay == 0 {
+		return func() tea.Msg { return check(time.Now()) }
+	}
+	return tea.Tick(delay, check)
+}
+
+// loadTemplate reads the user's template, or goes back to the default one
+// when there's no file anymore.
+func (d *Detail) loadTemplate() error {
+	b, err := os.ReadFile(d.templFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		d.templ = d.defaultTempl
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", d.templFile, err)
+	}
+
+	templ, err := template.New(filepath.Base(d.templFile)).Funcs(d.templFuncs).Parse(string(b))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s, keeping the previous layout: %w", d.templFile, err)
+	}
+	d.templ = templ
+	return nil
+}
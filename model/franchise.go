@@ -0,0 +1,235 @@
+package model
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+type franchiseMsg struct {
+	id        int
+	franchise *entity.Franchise
+}
+
+// Franchise is the hidden page showing what to watch, and in which order,
+// around the anime it was opened from.
+type Franchise struct {
+	*common
+
+	viewport  viewport.Model
+	franchise *entity.Franchise
+	// loading is the anime whose franchise is being walked, 0 when idle
+	loading   int
+	markdown  markdownRenderer
+	isFocused bool
+	keys      FranchiseKeyMap
+}
+
+func NewFranchise(c *common) *Franchise {
+	vp := viewport.New(0, 0)
+	vp.KeyMap = c.keyMaps.Franchise.KeyMap
+
+	return &Franchise{
+		common:   c,
+		viewport: vp,
+		keys:     c.keyMaps.Franchise,
+	}
+}
+
+func (f Franchise) Init() tea.Cmd { return nil }
+
+func (f *Franchise) Focus() { f.isFocused = true }
+func (f *Franchise) Blur()  { f.isFocused = false }
+
+// load walks the franchise, which takes a request for every entry that
+// isn't cached yet.
+func (f *Franchise) load(id int) tea.Cmd {
+	client := f.client
+	var load tea.Cmd
+	load = func() tea.Msg {
+		franchise, err := client.Franchise(id)
+		if err != nil {
+			return message.ErrMsg{
+				Err:   fmt.Errorf("failed to get the franchise of ID %d: %w", id, err),
+				Retry: load,
+			}
+		}
+		return franchiseMsg{id: id, franchise: franchise}
+	}
+	return load
+}
+
+func (f *Franchise) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		f.viewport.Width = msg.Width
+		f.viewport.Height = msg.Height
+		f.render()
+		return f, nil
+
+	case message.FranchiseMsg:
+		f.loading = msg.ID
+		return f, f.load(msg.ID)
+
+	case franchiseMsg:
+		// only the last one asked for is shown
+		if msg.id == f.loading {
+			f.loading = 0
+			f.franchise = msg.franchise
+			f.viewport.GotoTop()
+			f.render()
+		}
+		return f, nil
+
+	case message.ThemeMsg:
+		f.render()
+		return f, nil
+
+	case tea.MouseMsg:
+		if f.isFocused && leftClick(msg) && f.franchise != nil {
+			for _, e := range f.franchise.Entries {
+				if f.zone.Get(zoneAnimeLink(e.ID)).InBounds(msg) {
+					id := e.ID
+					return f, func() tea.Msg { return message.DetailMsg{ID: id} }
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	f.viewport, cmd = f.viewport.Update(msg)
+	return f, cmd
+}
+
+// render lists the franchise in release order, then draws the relations
+// as a tree from the anime it was opened from.
+func (f *Franchise) render() {
+	if f.franchise == nil {
+		f.viewport.SetContent("")
+		return
+	}
+
+	order := slices.Clone(f.franchise.Entries)
+	slices.SortStableFunc(order, compareRelease)
+
+	lines := []string{f.markdown.heading(f.theme, f.viewport.Width-2, "Watch order"), ""}
+	width := len(fmt.Sprint(len(order)))
+	for i, e := range order {
+		lines = append(lines, fmt.Sprintf("%*d. %s  %s", width, i+1, f.title(e), f.theme.Muted.Render(releaseInfo(e))))
+	}
+	if f.franchise.Truncated {
+		lines = append(lines, "", f.theme.Muted.Render("The franchise goes on, only the closest relations were followed."))
+	}
+
+	lines = append(lines,
+		f.theme.Separator.Render(strings.Repeat("─", f.viewport.Width)),
+		f.markdown.heading(f.theme, f.viewport.Width-2, "Relations"),
+		"",
+	)
+	lines = append(lines, f.tree()...)
+
+	f.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// tree draws every entry under the one it was first reached from,
+// siblings in release order.
+func (f *Franchise) tree() []string {
+	children := make(map[int][]entity.FranchiseEntry)
+	var root entity.FranchiseEntry
+	for _, e := range f.franchise.Entries {
+		if e.ID == f.franchise.Root {
+			root = e
+			continue
+		}
+		children[e.Parent] = append(children[e.Parent], e)
+	}
+	for _, c := range children {
+		slices.SortStableFunc(c, compareRelease)
+	}
+
+	lines := []string{f.title(root)}
+	var walk func(id int, prefix string)
+	walk = func(id int, prefix string) {
+		for i, e := range children[id] {
+			branch, indent := "├── ", "│   "
+			if i == len(children[id])-1 {
+				branch, indent = "└── ", "    "
+			}
+			lines = append(lines, fmt.Sprintf("%s%s %s %s",
+				f.theme.Separator.Render(prefix+branch),
+				f.theme.Muted.Render(e.Relation+":"),
+				f.title(e),
+				f.theme.Muted.Render("("+releaseYear(e)+")"),
+			))
+			walk(e.ID, prefix+indent)
+		}
+	}
+	walk(root.ID, "")
+	return lines
+}
+
+// title links the entry to its detail, the one the franchise was opened
+// from stands out.
+func (f *Franchise) title(e entity.FranchiseEntry) string {
+	title := e.AlternativeTitle.EngTitle
+	if title == "" {
+		title = e.Title
+	}
+	if e.ID == f.franchise.Root {
+		title = f.theme.Highlight.Render(title)
+	}
+	return f.zone.Mark(zoneAnimeLink(e.ID), title)
+}
+
+// compareRelease orders by start date, one only known to the year or month
+// comes before the ones known to the day. The unannounced ones go last.
+func compareRelease(a, b entity.FranchiseEntry) int {
+	switch {
+	case a.StartDate.IsZero() && !b.StartDate.IsZero():
+		return 1
+	case !a.StartDate.IsZero() && b.StartDate.IsZero():
+		return -1
+	}
+	return cmp.Or(a.StartDate.Compare(b.StartDate), a.ID-b.ID)
+}
+
+func releaseInfo(e entity.FranchiseEntry) string {
+	info := []string{e.MediaType.String()}
+	if !e.StartDate.IsZero() {
+		info = append(info, e.StartDate.String())
+	}
+	if e.Episodes > 0 {
+		info = append(info, fmt.Sprintf("%d eps", e.Episodes))
+	}
+	return strings.Join(info, " · ")
+}
+
+func releaseYear(e entity.FranchiseEntry) string {
+	if e.StartDate.IsZero() {
+		return "TBA"
+	}
+	return strconv.Itoa(e.StartDate.Year)
+}
+
+func (f Franchise) View() string {
+	if f.loading != 0 || f.franchise == nil {
+		text := fmt.Sprintf("Press %s on an anime's Detail to see its franchise.", f.keyMaps.Detail.Franchise.Help().Key)
+		if f.loading != 0 {
+			text = "Following the related anime..."
+		}
+		return lipgloss.NewStyle().
+			Width(f.viewport.Width).
+			Height(f.viewport.Height).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(text)
+	}
+	return f.viewport.View()
+}
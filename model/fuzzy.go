@@ -0,0 +1,32 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// fuzzyMatch reports whether every rune of pattern appears in s in the same
+// order, ignoring case, so "aot" matches "Attack on Titan".
+func fuzzyMatch(pattern, s string) bool {
+	pattern = strings.ToLower(pattern)
+	for _, c := range strings.ToLower(s) {
+		if pattern == "" {
+			break
+		}
+		p, size := utf8.DecodeRuneInString(pattern)
+		if c == p {
+			pattern = pattern[size:]
+		}
+	}
+	return pattern == ""
+}
+
+// fuzzyMatchAny reports whether pattern matches at least one of the candidates.
+func fuzzyMatchAny(pattern string, candidates ...string) bool {
+	for _, c := range candidates {
+		if c != "" && fuzzyMatch(pattern, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,503 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// Every keymap implements help.KeyMap so the footer and the help overlay are
+// generated from whatever keys are actually bound, including user overrides.
+
+type GlobalKeyMap struct {
+	NextTab key.Binding
+	PrevTab key.Binding
+	Help    key.Binding
+	Theme   key.Binding
+	Retry   key.Binding
+	Compare key.Binding
+	Logs    key.Binding
+	Media   key.Binding
+	Suspend key.Binding
+	Quit    key.Binding
+}
+
+func DefaultGlobalKeyMap() GlobalKeyMap {
+	return GlobalKeyMap{
+		NextTab: key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "next tab")),
+		PrevTab: key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "prev tab")),
+		Help:    key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Theme:   key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "next theme")),
+		Retry:   key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "retry")),
+		Compare: key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "compare")),
+		Logs:    key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "logs")),
+		Media:   key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "anime/manga")),
+		Suspend: key.NewBinding(key.WithKeys("ctrl+z"), key.WithHelp("ctrl+z", "suspend")),
+		Quit:    key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	}
+}
+
+func (k GlobalKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Help, k.Quit}
+}
+
+func (k GlobalKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.NextTab, k.PrevTab, k.Help, k.Theme, k.Retry, k.Compare, k.Logs, k.Media, k.Suspend, k.Quit}}
+}
+
+// InputKeyMap is used by every text input, e.g. the rank filter or the bookmark note.
+type InputKeyMap struct {
+	Accept key.Binding
+	Cancel key.Binding
+}
+
+func DefaultInputKeyMap() InputKeyMap {
+	return InputKeyMap{
+		Accept: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "apply")),
+		Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func (k InputKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Accept, k.Cancel}
+}
+
+func (k InputKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+type RankKeyMap struct {
+	table.KeyMap
+	Open        key.Binding
+	Bookmark    key.Binding
+	Compare     key.Binding
+	Browser     key.Binding
+	CopyLink    key.Binding
+	CopyTitle   key.Binding
+	CopySummary key.Binding
+	Sort        key.Binding
+	Reverse     key.Binding
+	// SortColumn sorts by the nth visible column, n being the position of
+	// the key pressed among its keys
+	SortColumn  key.Binding
+	Filter      key.Binding
+	ClearFilter key.Binding
+}
+
+func DefaultRankKeyMap() RankKeyMap {
+	km := table.DefaultKeyMap()
+	// b and space are taken by bookmark and open
+	km.PageUp = key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up"))
+	km.PageDown = key.NewBinding(key.WithKeys("f", "pgdown"), key.WithHelp("f/pgdn", "page down"))
+
+	return RankKeyMap{
+		KeyMap:      km,
+		Open:        key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "open detail")),
+		Bookmark:    key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "bookmark")),
+		Compare:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "mark to compare")),
+		Browser:     key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open in browser")),
+		CopyLink:    key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy link")),
+		CopyTitle:   key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "copy title")),
+		CopySummary: key.NewBinding(key.WithKeys("ctrl+y"), key.WithHelp("ctrl+y", "copy summary")),
+		Sort:        key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "next sort")),
+		Reverse:     key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "reverse sort")),
+		SortColumn:  key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "sort by column")),
+		Filter:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+		ClearFilter: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "clear filter")),
+	}
+}
+
+func (k RankKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.LineUp, k.LineDown, k.Open, k.Bookmark, k.Sort, k.Filter}
+}
+
+func (k RankKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.LineUp, k.LineDown, k.PageUp, k.PageDown, k.GotoTop, k.GotoBottom},
+		{k.Open, k.Bookmark, k.Compare},
+		{k.Browser, k.CopyLink, k.CopyTitle, k.CopySummary},
+		{k.Sort, k.Reverse, k.SortColumn},
+		{k.Filter, k.ClearFilter},
+	}
+}
+
+type DetailKeyMap struct {
+	viewport.KeyMap
+	Bookmark    key.Binding
+	Compare     key.Binding
+	Statistics  key.Binding
+	Franchise   key.Binding
+	Browser     key.Binding
+	CopyLink    key.Binding
+	CopyTitle   key.Binding
+	CopySummary key.Binding
+	Pictures    key.Binding
+	NextPicture key.Binding
+	PrevPicture key.Binding
+	SavePicture key.Binding
+	OpenPicture key.Binding
+}
+
+func DefaultDetailKeyMap() DetailKeyMap {
+	km := viewport.DefaultKeyMap()
+	// b is taken by bookmark
+	km.PageUp = key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up"))
+
+	return DetailKeyMap{
+		KeyMap:      km,
+		Bookmark:    key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "bookmark")),
+		Compare:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "mark to compare")),
+		Statistics:  key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "statistics")),
+		Franchise:   key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "watch order")),
+		Browser:     key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open in browser")),
+		CopyLink:    key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy link")),
+		CopyTitle:   key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "copy title")),
+		CopySummary: key.NewBinding(key.WithKeys("ctrl+y"), key.WithHelp("ctrl+y", "copy summary")),
+		Pictures:    key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "pictures")),
+		NextPicture: key.NewBinding(key.WithKeys("n", "]"), key.WithHelp("n", "next picture")),
+		PrevPicture: key.NewBinding(key.WithKeys("p", "["), key.WithHelp("p", "prev picture")),
+		SavePicture: key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save picture")),
+		OpenPicture: key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "open in viewer")),
+	}
+}
+
+func (k DetailKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Bookmark, k.Statistics}
+}
+
+func (k DetailKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown},
+		{k.Bookmark, k.Compare, k.Statistics, k.Franchise, k.Pictures},
+		{k.Browser, k.CopyLink, k.CopyTitle, k.CopySummary},
+		{k.NextPicture, k.PrevPicture, k.SavePicture, k.OpenPicture},
+	}
+}
+
+// detailPicturesHelp puts the picture bindings first while they're usable.
+type detailPicturesHelp struct{ DetailKeyMap }
+
+func (k detailPicturesHelp) ShortHelp() []key.Binding {
+	return []key.Binding{k.NextPicture, k.PrevPicture, k.SavePicture, k.OpenPicture, k.Pictures}
+}
+
+type BookmarksKeyMap struct {
+	table.KeyMap
+	Open   key.Binding
+	Remove key.Binding
+	Note   key.Binding
+	Tags   key.Binding
+}
+
+func DefaultBookmarksKeyMap() BookmarksKeyMap {
+	km := table.DefaultKeyMap()
+	// b, d and space are taken by remove and open
+	km.PageUp = key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up"))
+	km.PageDown = key.NewBinding(key.WithKeys("f", "pgdown"), key.WithHelp("f/pgdn", "page down"))
+	km.HalfPageDown = key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "½ page down"))
+
+	return BookmarksKeyMap{
+		KeyMap: km,
+		Open:   key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "open detail")),
+		Remove: key.NewBinding(key.WithKeys("d", "b"), key.WithHelp("d", "remove")),
+		Note:   key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "edit note")),
+		Tags:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "edit tags")),
+	}
+}
+
+func (k BookmarksKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.LineUp, k.LineDown, k.Open, k.Remove, k.Note, k.Tags}
+}
+
+func (k BookmarksKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.LineUp, k.LineDown, k.PageUp, k.PageDown, k.GotoTop, k.GotoBottom},
+		{k.Open, k.Remove, k.Note, k.Tags},
+	}
+}
+
+type SearchKeyMap struct {
+	table.KeyMap
+	Search   key.Binding
+	Open     key.Binding
+	Bookmark key.Binding
+	Compare  key.Binding
+}
+
+func DefaultSearchKeyMap() SearchKeyMap {
+	km := table.DefaultKeyMap()
+	// b and space are taken by bookmark and open
+	km.PageUp = key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up"))
+	km.PageDown = key.NewBinding(key.WithKeys("f", "pgdown"), key.WithHelp("f/pgdn", "page down"))
+
+	return SearchKeyMap{
+		KeyMap:   km,
+		Search:   key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		Open:     key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "open detail")),
+		Bookmark: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "bookmark")),
+		Compare:  key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "mark to compare")),
+	}
+}
+
+func (k SearchKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.LineUp, k.LineDown, k.Search, k.Open, k.Bookmark, k.Compare}
+}
+
+func (k SearchKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.LineUp, k.LineDown, k.PageUp, k.PageDown, k.GotoTop, k.GotoBottom},
+		{k.Search, k.Open, k.Bookmark, k.Compare},
+	}
+}
+
+type CompareKeyMap struct {
+	viewport.KeyMap
+	Remove key.Binding
+	Clear  key.Binding
+}
+
+func DefaultCompareKeyMap() CompareKeyMap {
+	return CompareKeyMap{
+		KeyMap: viewport.DefaultKeyMap(),
+		Remove: key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "remove column")),
+		Clear:  key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "clear")),
+	}
+}
+
+func (k CompareKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Remove, k.Clear}
+}
+
+func (k CompareKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown},
+		{k.Remove, k.Clear},
+	}
+}
+
+type ScheduleKeyMap struct {
+	viewport.KeyMap
+}
+
+func DefaultScheduleKeyMap() ScheduleKeyMap {
+	return ScheduleKeyMap{KeyMap: viewport.DefaultKeyMap()}
+}
+
+func (k ScheduleKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.PageDown, k.PageUp}
+}
+
+func (k ScheduleKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown}}
+}
+
+type FranchiseKeyMap struct {
+	viewport.KeyMap
+}
+
+func DefaultFranchiseKeyMap() FranchiseKeyMap {
+	return FranchiseKeyMap{KeyMap: viewport.DefaultKeyMap()}
+}
+
+func (k FranchiseKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.PageDown, k.PageUp}
+}
+
+func (k FranchiseKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown}}
+}
+
+type LogsKeyMap struct {
+	viewport.KeyMap
+	Level key.Binding
+}
+
+func DefaultLogsKeyMap() LogsKeyMap {
+	km := viewport.DefaultKeyMap()
+	// f is taken by the level filter
+	km.PageDown = key.NewBinding(key.WithKeys("pgdown", " "), key.WithHelp("pgdn", "page down"))
+
+	return LogsKeyMap{
+		KeyMap: km,
+		Level:  key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter level")),
+	}
+}
+
+func (k LogsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Level}
+}
+
+func (k LogsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown},
+		{k.Level},
+	}
+}
+
+// KeyMaps groups the keymap of every page so they can be overridden together.
+type KeyMaps struct {
+	Global    GlobalKeyMap
+	Input     InputKeyMap
+	Rank      RankKeyMap
+	Detail    DetailKeyMap
+	Bookmarks BookmarksKeyMap
+	Search    SearchKeyMap
+	Schedule  ScheduleKeyMap
+	Compare   CompareKeyMap
+	Franchise FranchiseKeyMap
+	Logs      LogsKeyMap
+}
+
+func DefaultKeyMaps() KeyMaps {
+	return KeyMaps{
+		Global:    DefaultGlobalKeyMap(),
+		Input:     DefaultInputKeyMap(),
+		Rank:      DefaultRankKeyMap(),
+		Detail:    DefaultDetailKeyMap(),
+		Bookmarks: DefaultBookmarksKeyMap(),
+		Search:    DefaultSearchKeyMap(),
+		Schedule:  DefaultScheduleKeyMap(),
+		Compare:   DefaultCompareKeyMap(),
+		Franchise: DefaultFranchiseKeyMap(),
+		Logs:      DefaultLogsKeyMap(),
+	}
+}
+
+// bindings names every binding that can be overridden from the config file.
+func (k *KeyMaps) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"global.next_tab": &k.Global.NextTab,
+		"global.prev_tab": &k.Global.PrevTab,
+		"global.help":     &k.Global.Help,
+		"global.theme":    &k.Global.Theme,
+		"global.retry":    &k.Global.Retry,
+		"global.compare":  &k.Global.Compare,
+		"global.logs":     &k.Global.Logs,
+		"global.media":    &k.Global.Media,
+		"global.suspend":  &k.Global.Suspend,
+		"global.quit":     &k.Global.Quit,
+
+		"input.accept": &k.Input.Accept,
+		"input.cancel": &k.Input.Cancel,
+
+		"rank.up":           &k.Rank.LineUp,
+		"rank.down":         &k.Rank.LineDown,
+		"rank.page_up":      &k.Rank.PageUp,
+		"rank.page_down":    &k.Rank.PageDown,
+		"rank.half_up":      &k.Rank.HalfPageUp,
+		"rank.half_down":    &k.Rank.HalfPageDown,
+		"rank.top":          &k.Rank.GotoTop,
+		"rank.bottom":       &k.Rank.GotoBottom,
+		"rank.open":         &k.Rank.Open,
+		"rank.bookmark":     &k.Rank.Bookmark,
+		"rank.compare":      &k.Rank.Compare,
+		"rank.browser":      &k.Rank.Browser,
+		"rank.copy_link":    &k.Rank.CopyLink,
+		"rank.copy_title":   &k.Rank.CopyTitle,
+		"rank.copy_summary": &k.Rank.CopySummary,
+		"rank.sort":         &k.Rank.Sort,
+		"rank.reverse":      &k.Rank.Reverse,
+		"rank.sort_column":  &k.Rank.SortColumn,
+		"rank.filter":       &k.Rank.Filter,
+		"rank.clear_filter": &k.Rank.ClearFilter,
+
+		"detail.up":           &k.Detail.Up,
+		"detail.down":         &k.Detail.Down,
+		"detail.page_up":      &k.Detail.PageUp,
+		"detail.page_down":    &k.Detail.PageDown,
+		"detail.half_up":      &k.Detail.HalfPageUp,
+		"detail.half_down":    &k.Detail.HalfPageDown,
+		"detail.bookmark":     &k.Detail.Bookmark,
+		"detail.compare":      &k.Detail.Compare,
+		"detail.statistics":   &k.Detail.Statistics,
+		"detail.franchise":    &k.Detail.Franchise,
+		"detail.browser":      &k.Detail.Browser,
+		"detail.copy_link":    &k.Detail.CopyLink,
+		"detail.copy_title":   &k.Detail.CopyTitle,
+		"detail.copy_summary": &k.Detail.CopySummary,
+		"detail.pictures":     &k.Detail.Pictures,
+		"detail.next_picture": &k.Detail.NextPicture,
+		"detail.prev_picture": &k.Detail.PrevPicture,
+		"detail.save_picture": &k.Detail.SavePicture,
+		"detail.open_picture": &k.Detail.OpenPicture,
+
+		"bookmarks.up":        &k.Bookmarks.LineUp,
+		"bookmarks.down":      &k.Bookmarks.LineDown,
+		"bookmarks.page_up":   &k.Bookmarks.PageUp,
+		"bookmarks.page_down": &k.Bookmarks.PageDown,
+		"bookmarks.half_up":   &k.Bookmarks.HalfPageUp,
+		"bookmarks.half_down": &k.Bookmarks.HalfPageDown,
+		"bookmarks.top":       &k.Bookmarks.GotoTop,
+		"bookmarks.bottom":    &k.Bookmarks.GotoBottom,
+		"bookmarks.open":      &k.Bookmarks.Open,
+		"bookmarks.remove":    &k.Bookmarks.Remove,
+		"bookmarks.note":      &k.Bookmarks.Note,
+		"bookmarks.tags":      &k.Bookmarks.Tags,
+
+		"search.up":        &k.Search.LineUp,
+		"search.down":      &k.Search.LineDown,
+		"search.page_up":   &k.Search.PageUp,
+		"search.page_down": &k.Search.PageDown,
+		"search.half_up":   &k.Search.HalfPageUp,
+		"search.half_down": &k.Search.HalfPageDown,
+		"search.top":       &k.Search.GotoTop,
+		"search.bottom":    &k.Search.GotoBottom,
+		"search.search":    &k.Search.Search,
+		"search.open":      &k.Search.Open,
+		"search.bookmark":  &k.Search.Bookmark,
+		"search.compare":   &k.Search.Compare,
+
+		"schedule.up":        &k.Schedule.Up,
+		"schedule.down":      &k.Schedule.Down,
+		"schedule.page_up":   &k.Schedule.PageUp,
+		"schedule.page_down": &k.Schedule.PageDown,
+		"schedule.half_up":   &k.Schedule.HalfPageUp,
+		"schedule.half_down": &k.Schedule.HalfPageDown,
+
+		"compare.up":        &k.Compare.Up,
+		"compare.down":      &k.Compare.Down,
+		"compare.page_up":   &k.Compare.PageUp,
+		"compare.page_down": &k.Compare.PageDown,
+		"compare.half_up":   &k.Compare.HalfPageUp,
+		"compare.half_down": &k.Compare.HalfPageDown,
+		"compare.remove":    &k.Compare.Remove,
+		"compare.clear":     &k.Compare.Clear,
+
+		"franchise.up":        &k.Franchise.Up,
+		"franchise.down":      &k.Franchise.Down,
+		"franchise.page_up":   &k.Franchise.PageUp,
+		"franchise.page_down": &k.Franchise.PageDown,
+		"franchise.half_up":   &k.Franchise.HalfPageUp,
+		"franchise.half_down": &k.Franchise.HalfPageDown,
+
+		"logs.up":        &k.Logs.Up,
+		"logs.down":      &k.Logs.Down,
+		"logs.page_up":   &k.Logs.PageUp,
+		"logs.page_down": &k.Logs.PageDown,
+		"logs.half_up":   &k.Logs.HalfPageUp,
+		"logs.half_down": &k.Logs.HalfPageDown,
+		"logs.level":     &k.Logs.Level,
+	}
+}
+
+// Override rebinds the named bindings, e.g. {"global.quit": ["q", "ctrl+c"]}.
+// The help text keeps its description and shows the new keys.
+func (k *KeyMaps) Override(overrides map[string][]string) error {
+	bindings := k.bindings()
+	for name, keys := range overrides {
+		b, ok := bindings[name]
+		if !ok {
+			return fmt.Errorf("unknown key binding %q", name)
+		}
+		if len(keys) == 0 {
+			b.SetEnabled(false)
+			continue
+		}
+		b.SetKeys(keys...)
+		b.SetHelp(strings.Join(keys, "/"), b.Help().Desc)
+	}
+	return nil
+}
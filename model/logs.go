@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/logger"
+	"github.com/izzanzahrial/tui/message"
+)
+
+// Logs is the hidden page listing what the logger has kept in memory.
+type Logs struct {
+	*common
+
+	viewport  viewport.Model
+	level     logger.Level
+	count     int
+	seq       uint64
+	isFocused bool
+	keys      LogsKeyMap
+}
+
+func NewLogs(c *common) *Logs {
+	vp := viewport.New(0, 0)
+	vp.KeyMap = c.keyMaps.Logs.KeyMap
+
+	return &Logs{
+		common:   c,
+		viewport: vp,
+		keys:     c.keyMaps.Logs,
+	}
+}
+
+func (l Logs) Init() tea.Cmd { return nil }
+
+func (l *Logs) Focus() {
+	if !l.isFocused {
+		l.isFocused = true
+		l.refresh()
+	}
+}
+
+func (l *Logs) Blur() { l.isFocused = false }
+
+func (l *Logs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		l.viewport.Width = msg.Width
+		// leave one line for the level filter
+		l.viewport.Height = msg.Height - 1
+		l.refresh()
+		return l, nil
+
+	case message.ThemeMsg:
+		l.refresh()
+		return l, nil
+
+	case tea.KeyMsg:
+		if !l.isFocused {
+			return l, nil
+		}
+
+		if key.Matches(msg, l.keys.Level) {
+			l.level = (l.level + 1) % (logger.LevelError + 1)
+			l.refresh()
+			return l, nil
+		}
+	}
+
+	// pick up whatever was logged since the last message
+	if l.log.Seq() != l.seq {
+		l.refresh()
+	}
+
+	var cmd tea.Cmd
+	l.viewport, cmd = l.viewport.Update(msg)
+	return l, cmd
+}
+
+// refresh renders the entries at the selected level, following the bottom
+// of the log unless the user scrolled up.
+func (l *Logs) refresh() {
+	// read the sequence first, an entry logged in between is only drawn twice
+	l.seq = l.log.Seq()
+	entries := l.log.Entries(l.level)
+	l.count = len(entries)
+
+	follow := l.viewport.AtBottom()
+
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		st := l.theme.Base
+		switch e.Level {
+		case logger.LevelWarn:
+			st = l.theme.StatusWarning
+		case logger.LevelError:
+			st = l.theme.StatusError
+		}
+		lines[i] = fmt.Sprintf("%s %s %s",
+			l.theme.Muted.Render(e.Time.Format("15:04:05")),
+			st.Render(fmt.Sprintf("%-5s", e.Level)),
+			e.Message,
+		)
+	}
+	l.viewport.SetContent(strings.Join(lines, "\n"))
+
+	if follow {
+		l.viewport.GotoBottom()
+	}
+}
+
+func (l Logs) View() string {
+	status := l.theme.Muted.Render(fmt.Sprintf("level: %s and above • %d entries", l.level, l.count))
+
+	if l.count == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().
+				Width(l.viewport.Width).
+				Height(l.viewport.Height).
+				Align(lipgloss.Center, lipgloss.Center).
+				Render("Nothing logged yet."),
+			status,
+		)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, l.viewport.View(), status)
+}
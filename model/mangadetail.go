@@ -0,0 +1,243 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+const mangaTemplate = `{{.Title}}
+> Published: {{.Published}} | Status: {{.Status}}
+
+## Overview
+Rank: {{.Rank}} | Popularity: {{.Popularity}} | Volumes: {{.Volumes}} | Chapters: {{.Chapters}}
+Genres: {{.Genres}}
+Authors: {{.Authors}}
+{{if .Serialization}}Serialization: {{.Serialization}}
+{{end}}{{.Separator}}
+{{if .Synopsis}}
+## Synopsis
+
+{{.Synopsis}}
+{{end}}
+{{if .Background}}
+{{.Separator}}
+## Background
+
+{{.Background}}
+{{end}}
+{{if .RelatedMangas}}
+{{.Separator}}
+## Related Manga
+{{range .RelatedMangas}}
+- {{mangaLink .Node}} ({{.RelationType}})
+{{end}}
+{{end}}
+{{if .RelatedAnimes}}
+{{.Separator}}
+## Adaptations
+{{range .RelatedAnimes}}
+- {{link .Node}} ({{.RelationType}})
+{{end}}
+{{end}}`
+
+type mangaTemplateData struct {
+	Title         string
+	Published     string
+	Status        string
+	Rank          int
+	Popularity    int
+	Volumes       string
+	Chapters      string
+	Genres        string
+	Authors       string
+	Serialization string
+	Synopsis      string
+	Background    string
+	RelatedMangas []entity.RelatedManga
+	RelatedAnimes []entity.RelatedAnime
+	Separator     string
+}
+
+// MangaDetail is the Detail tab while browsing manga.
+type MangaDetail struct {
+	*common
+
+	viewport  viewport.Model
+	current   *entity.MangaDetail
+	templ     *template.Template
+	isFocused bool
+	keys      DetailKeyMap
+}
+
+func NewMangaDetail(c *common) *MangaDetail {
+	funcs := template.FuncMap{
+		"link":      func(n entity.Node) string { return c.zone.Mark(zoneAnimeLink(n.ID), n.Title) },
+		"mangaLink": func(n entity.Node) string { return c.zone.Mark(zoneMangaLink(n.ID), n.Title) },
+	}
+
+	templ, err := template.New("manga_detail").Funcs(funcs).Parse(mangaTemplate)
+	if err != nil {
+		panic(err)
+	}
+
+	vp := viewport.New(0, 0)
+	vp.KeyMap = c.keyMaps.Detail.KeyMap
+
+	return &MangaDetail{
+		common:   c,
+		viewport: vp,
+		templ:    templ,
+		keys:     c.keyMaps.Detail,
+	}
+}
+
+func (d MangaDetail) Init() tea.Cmd { return nil }
+
+func (d *MangaDetail) Focus() { d.isFocused = true }
+func (d *MangaDetail) Blur()  { d.isFocused = false }
+
+// KeyMap lists only the bindings manga support, bookmarks and the
+// anime-only views are left out.
+func (d *MangaDetail) KeyMap() help.KeyMap { return mangaDetailHelp{d.keys} }
+
+type mangaDetailHelp struct{ DetailKeyMap }
+
+func (k mangaDetailHelp) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.PageDown, k.PageUp}
+}
+
+func (k mangaDetailHelp) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown}}
+}
+
+func (d *MangaDetail) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		d.viewport.Width = msg.Width
+		d.viewport.Height = msg.Height - lipgloss.Height(d.footerView())
+		return d, d.refresh()
+
+	case tea.MouseMsg:
+		if d.isFocused && leftClick(msg) {
+			if cmd := d.click(msg); cmd != nil {
+				return d, cmd
+			}
+		}
+
+	case message.ThemeMsg:
+		return d, d.refresh()
+
+	case message.MangaDetailMsg:
+		d.viewport.GotoTop()
+		detail, err := d.client.MangaDetail(msg.ID)
+		if err != nil {
+			return d, func() tea.Msg {
+				return message.ErrMsg{
+					Err:   fmt.Errorf("failed to get manga detail for ID %d: %w", msg.ID, err),
+					Retry: func() tea.Msg { return msg },
+				}
+			}
+		}
+		d.current = detail
+		return d, d.refresh()
+	}
+
+	var cmd tea.Cmd
+	d.viewport, cmd = d.viewport.Update(msg)
+	return d, cmd
+}
+
+// click follows the related manga and adaptations under the mouse, if any.
+func (d *MangaDetail) click(msg tea.MouseMsg) tea.Cmd {
+	if d.current == nil {
+		return nil
+	}
+
+	for _, v := range d.current.RelatedMangas {
+		if d.zone.Get(zoneMangaLink(v.Node.ID)).InBounds(msg) {
+			id := v.Node.ID
+			return func() tea.Msg { return message.MangaDetailMsg{ID: id} }
+		}
+	}
+	for _, v := range d.current.RelatedAnimes {
+		if d.zone.Get(zoneAnimeLink(v.Node.ID)).InBounds(msg) {
+			id := v.Node.ID
+			return func() tea.Msg { return message.DetailMsg{ID: id} }
+		}
+	}
+	return nil
+}
+
+func (d *MangaDetail) refresh() tea.Cmd {
+	if d.current == nil {
+		return nil
+	}
+
+	data := d.current
+	contentStyle := lipgloss.NewStyle().Width(d.viewport.Width - 2)
+
+	title := data.AlternativeTitle.EngTitle
+	if title == "" {
+		title = data.Title
+	}
+	published := data.StartDate.String()
+	if !data.EndDate.IsZero() {
+		published += " to " + data.EndDate.String()
+	}
+
+	var buf bytes.Buffer
+	err := d.templ.Execute(&buf, mangaTemplateData{
+		Title:         d.theme.DetailTitle.Render(title),
+		Published:     published,
+		Status:        data.Status.String(),
+		Rank:          data.Rank,
+		Popularity:    data.Popularity,
+		Volumes:       countOrUnknown(data.Volumes),
+		Chapters:      countOrUnknown(data.Chapters),
+		Genres:        joinNames(data.Genres),
+		Authors:       joinNames(data.Authors),
+		Serialization: joinNames(data.Serialization),
+		Synopsis:      contentStyle.Render(data.Synopsis),
+		Background:    contentStyle.Render(data.Background),
+		RelatedMangas: data.RelatedMangas,
+		RelatedAnimes: data.RelatedAnimes,
+		Separator:     d.theme.Separator.Render(strings.Repeat("─", d.viewport.Width)),
+	})
+	if err != nil {
+		// the template is ours, if it fails there's nothing the user can do
+		return func() tea.Msg {
+			return message.ErrMsg{Err: fmt.Errorf("failed to execute template: %w", err), Fatal: true}
+		}
+	}
+
+	d.viewport.SetContent(buf.String())
+	return nil
+}
+
+func (d MangaDetail) View() string {
+	if d.current == nil {
+		return lipgloss.NewStyle().
+			Width(d.viewport.Width).
+			Height(d.viewport.Height).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render("Select a manga from the Rank page.")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, d.viewport.View(), d.footerView())
+}
+
+func (d MangaDetail) footerView() string {
+	info := d.theme.Info.Render(fmt.Sprintf("%3.f%%", d.viewport.ScrollPercent()*100))
+	line := strings.Repeat("─", max(0, d.viewport.Width-lipgloss.Width(info)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, line, info)
+}
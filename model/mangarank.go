@@ -0,0 +1,251 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+type mangaColumn struct {
+	rankColumn
+	value func(entity.MangaRank) string
+}
+
+// mangaColumns reuse the rank table layout, see layoutColumns.
+var mangaColumns = []mangaColumn{
+	{
+		rankColumn: rankColumn{title: "Rank", minWidth: 4, priority: 0},
+		value:      func(m entity.MangaRank) string { return strconv.Itoa(m.Rank.Rank) },
+	},
+	{
+		rankColumn: rankColumn{title: "Title", weight: 4, minWidth: 20, priority: 0},
+		value:      func(m entity.MangaRank) string { return mangaTitle(m.Manga) },
+	},
+	{
+		rankColumn: rankColumn{title: "Score", minWidth: 5, priority: 1},
+		value: func(m entity.MangaRank) string {
+			if m.Manga.Mean == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.2f", m.Manga.Mean)
+		},
+	},
+	{
+		rankColumn: rankColumn{title: "Members", minWidth: 9, priority: 2},
+		value:      func(m entity.MangaRank) string { return humanizeCount(m.Manga.Members) },
+	},
+	{
+		rankColumn: rankColumn{title: "Vols", minWidth: 4, priority: 2},
+		value:      func(m entity.MangaRank) string { return countOrUnknown(m.Manga.Volumes) },
+	},
+	{
+		rankColumn: rankColumn{title: "Chs", minWidth: 4, priority: 3},
+		value:      func(m entity.MangaRank) string { return countOrUnknown(m.Manga.Chapters) },
+	},
+	{
+		rankColumn: rankColumn{title: "Type", minWidth: 9, priority: 3},
+		value:      func(m entity.MangaRank) string { return m.Manga.MediaType.String() },
+	},
+	{
+		rankColumn: rankColumn{title: "Japanese Title", weight: 3, minWidth: 20, priority: 4},
+		value:      func(m entity.MangaRank) string { return m.Manga.AlternativeTitle.JpnTitle },
+	},
+}
+
+// MangaRank is the Rank tab while browsing manga. It's driven by the
+// rank bindings, without the sorting and filtering of the anime table.
+type MangaRank struct {
+	*common
+
+	manga     []entity.MangaRank
+	isLoading bool
+	requested bool
+	spinner   spinner.Model
+	table     *table.Model
+	shown     []mangaColumn
+	keys      RankKeyMap
+}
+
+func NewMangaRank(c *common) *MangaRank {
+	t := table.New(
+		table.WithFocused(true),
+		table.WithHeight(10),
+		table.WithKeyMap(c.keyMaps.Rank.KeyMap),
+	)
+
+	r := &MangaRank{
+		common:    c,
+		isLoading: true,
+		spinner:   spinner.New(spinner.WithSpinner(spinner.Dot)),
+		table:     &t,
+		keys:      c.keyMaps.Rank,
+	}
+	r.layout(100)
+	r.applyTheme()
+	return r
+}
+
+func (r *MangaRank) applyTheme() {
+	r.spinner.Style = r.theme.Spinner
+	r.table.SetStyles(table.Styles{
+		Header:   r.theme.TableHeader,
+		Cell:     r.theme.TableCell,
+		Selected: r.theme.TableSelected,
+	})
+}
+
+func (r MangaRank) Init() tea.Cmd { return nil }
+
+// Request fetches the ranking the first time the manga are browsed.
+func (r *MangaRank) Request() tea.Cmd {
+	if r.requested {
+		return nil
+	}
+	r.requested = true
+	return tea.Batch(r.spinner.Tick, r.request)
+}
+
+func (r MangaRank) request() tea.Msg {
+	data, err := r.client.MangaRank(0, nil, nil)
+	if err != nil {
+		return message.ErrMsg{Err: fmt.Errorf("failed to fetch manga ranks: %w", err), Retry: r.request}
+	}
+	return data
+}
+
+func (r *MangaRank) Focus() { r.table.Focus() }
+func (r *MangaRank) Blur()  { r.table.Blur() }
+
+// KeyMap lists only the bindings the manga table supports.
+func (r *MangaRank) KeyMap() help.KeyMap { return mangaRankHelp{r.keys} }
+
+type mangaRankHelp struct{ RankKeyMap }
+
+func (k mangaRankHelp) ShortHelp() []key.Binding {
+	return []key.Binding{k.LineUp, k.LineDown, k.Open}
+}
+
+func (k mangaRankHelp) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.LineUp, k.LineDown, k.PageUp, k.PageDown, k.GotoTop, k.GotoBottom},
+		{k.Open},
+	}
+}
+
+// layout fits the columns in width, the rows are rebuilt to match.
+func (r *MangaRank) layout(width int) {
+	cols := make([]rankColumn, len(mangaColumns))
+	for i, c := range mangaColumns {
+		cols[i] = c.rankColumn
+	}
+	laid := layoutColumns(cols, width)
+
+	// layoutColumns keeps the order, so the values can be matched back by title
+	r.shown = r.shown[:0]
+	for _, c := range laid {
+		for _, mc := range mangaColumns {
+			if mc.title == c.title {
+				mc.rankColumn = c
+				r.shown = append(r.shown, mc)
+			}
+		}
+	}
+
+	cursor := r.table.Cursor()
+	r.table.SetRows(nil)
+	r.table.SetColumns(tableColumns(laid))
+	r.refresh()
+	r.table.SetCursor(min(max(0, cursor), max(0, len(r.manga)-1)))
+}
+
+func (r *MangaRank) refresh() {
+	rows := make([]table.Row, len(r.manga))
+	for i, m := range r.manga {
+		row := make(table.Row, len(r.shown))
+		for j, c := range r.shown {
+			row[j] = c.value(m)
+		}
+		rows[i] = row
+	}
+	r.table.SetRows(rows)
+}
+
+func (r *MangaRank) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		width := msg.Width - r.theme.Frame.GetHorizontalFrameSize()
+		r.layout(width)
+		r.table.SetWidth(width)
+		// leave one line for the status, like the anime table
+		r.table.SetHeight(msg.Height - 1)
+		return r, nil
+
+	case message.ThemeMsg:
+		r.applyTheme()
+		return r, nil
+
+	case *entity.MangaData:
+		r.manga = msg.MangaRank
+		r.isLoading = false
+		r.refresh()
+		r.table.SetCursor(0)
+		return r, nil
+
+	case tea.KeyMsg:
+		if !r.table.Focused() || r.isLoading {
+			return r, nil
+		}
+
+		if key.Matches(msg, r.keys.Open) {
+			i := r.table.Cursor()
+			if i < 0 || i >= len(r.manga) {
+				return r, nil
+			}
+			id := r.manga[i].Manga.ID
+			return r, func() tea.Msg { return message.MangaDetailMsg{ID: id} }
+		}
+	}
+
+	if r.isLoading {
+		r.spinner, cmd = r.spinner.Update(msg)
+		return r, cmd
+	}
+
+	*r.table, cmd = r.table.Update(msg)
+	return r, cmd
+}
+
+func (r MangaRank) View() string {
+	if r.isLoading {
+		loadingStyle := lipgloss.NewStyle().Width(r.table.Width()).Height(r.table.Height()).Align(lipgloss.Center, lipgloss.Center)
+		return loadingStyle.Render(lipgloss.JoinHorizontal(lipgloss.Center, r.spinner.View(), " Loading..."))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		r.theme.Frame.Align(lipgloss.Left).Render(r.table.View()),
+		r.theme.Muted.Render(fmt.Sprintf("manga ranking • %d titles", len(r.manga))),
+	)
+}
+
+func mangaTitle(m entity.Manga) string {
+	if m.AlternativeTitle.EngTitle != "" {
+		return m.AlternativeTitle.EngTitle
+	}
+	return m.Title
+}
+
+func countOrUnknown(n int) string {
+	if n == 0 {
+		return "?"
+	}
+	return strconv.Itoa(n)
+}
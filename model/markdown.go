@@ -0,0 +1,130 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/izzanzahrial/tui/style"
+)
+
+var (
+	paragraphBreak = regexp.MustCompile(`\n[ \t]*\n`)
+	// attribution is the line MAL ends its own synopses with,
+	// e.g. [Written by MAL Rewrite]
+	attribution = regexp.MustCompile(`^\[(Written by [^\]]+)\]$`)
+	// sourceLine is a paragraph citing where the text comes from,
+	// e.g. (Source: Crunchyroll)
+	sourceLine = regexp.MustCompile(`(?i)^\(?(sources?:[^()]+)\)?$`)
+	// sourceSuffix is a citation at the end of a paragraph
+	sourceSuffix = regexp.MustCompile(`(?i)\s*\((sources?:[^()]+)\)$`)
+)
+
+// markdownEscaper keeps MAL's plain text from being read as Markdown, a
+// synopsis starting with "1." or quoting *emphasis* must show as it is.
+var markdownEscaper = func() *strings.Replacer {
+	var pairs []string
+	for _, c := range "\\`*_[]<>#+-.!|~&=" {
+		pairs = append(pairs, string(c), `\`+string(c))
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// footnotes turns MAL's plain text into Markdown. Paragraphs stay as they
+// are, while the attribution and source lines move to numbered footnotes
+// at the end, marked where they were.
+func footnotes(text string) string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+
+	var paragraphs, notes []string
+	note := func(n string) {
+		notes = append(notes, n)
+		if len(paragraphs) > 0 {
+			paragraphs[len(paragraphs)-1] += superscript(len(notes))
+		}
+	}
+
+	for _, p := range paragraphBreak.Split(text, -1) {
+		p = strings.TrimSpace(p)
+		switch {
+		case p == "":
+		case attribution.MatchString(p):
+			note(attribution.FindStringSubmatch(p)[1])
+		case sourceLine.MatchString(p):
+			note(capitalize(sourceLine.FindStringSubmatch(p)[1]))
+		case sourceSuffix.MatchString(p):
+			source := sourceSuffix.FindStringSubmatch(p)[1]
+			paragraphs = append(paragraphs, markdownEscaper.Replace(sourceSuffix.ReplaceAllString(p, "")))
+			note(capitalize(source))
+		default:
+			paragraphs = append(paragraphs, markdownEscaper.Replace(p))
+		}
+	}
+
+	if len(notes) == 0 {
+		return strings.Join(paragraphs, "\n\n")
+	}
+	lines := make([]string, len(notes))
+	for i, n := range notes {
+		lines[i] = fmt.Sprintf("*%s %s*", superscript(i+1), markdownEscaper.Replace(n))
+	}
+	return strings.Join(append(paragraphs, "---", strings.Join(lines, "\n")), "\n\n")
+}
+
+func superscript(n int) string {
+	const digits = "⁰¹²³⁴⁵⁶⁷⁸⁹"
+	var b strings.Builder
+	for _, d := range fmt.Sprint(n) {
+		b.WriteString(string([]rune(digits)[d-'0']))
+	}
+	return b.String()
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// markdownRenderer renders Markdown for a width and a theme, the glamour
+// renderer is only built again when either changes.
+type markdownRenderer struct {
+	renderer *glamour.TermRenderer
+	width    int
+	theme    string
+}
+
+func (m *markdownRenderer) render(theme *style.Theme, width int, md string) (string, error) {
+	key := fmt.Sprintf("%s:%t", theme.Name, theme.NoColor)
+	if m.renderer == nil || m.width != width || m.theme != key {
+		r, err := glamour.NewTermRenderer(
+			glamour.WithStyles(theme.Markdown),
+			glamour.WithColorProfile(theme.ColorProfile),
+			glamour.WithWordWrap(max(1, width)),
+			// MAL breaks lines on purpose, e.g. to list the cast
+			glamour.WithPreservedNewLines(),
+		)
+		if err != nil {
+			return "", err
+		}
+		m.renderer, m.width, m.theme = r, width, key
+	}
+
+	out, err := m.renderer.Render(md)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(out, "\n"), nil
+}
+
+// heading renders s as a Markdown heading, the way the detail template's
+// heading does. The plain text is used if glamour fails on it.
+func (m *markdownRenderer) heading(theme *style.Theme, width int, s string) string {
+	out, err := m.render(theme, width, "## "+markdownEscaper.Replace(s))
+	if err != nil {
+		return s
+	}
+	return out
+}
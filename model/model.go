@@ -1,15 +1,24 @@
 package model
 
 import (
-	"log"
+	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/izzanzahrial/tui/bookmark"
+	"github.com/izzanzahrial/tui/config"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/logger"
 	"github.com/izzanzahrial/tui/message"
 	"github.com/izzanzahrial/tui/style"
 	"github.com/izzanzahrial/tui/url"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/muesli/termenv"
 )
 
 const (
@@ -17,64 +26,234 @@ const (
 	mainHeaderHeight = 2
 	// Any additional vertical padding for the main content area.
 	mainVerticalPadding = 1
+	// How much vertical space the status bar and the short help occupy.
+	mainFooterHeight = 2
+	// Lines kept free below the app when rendering inline, so the prompt
+	// printed after quitting doesn't scroll the title out of view.
+	inlineBottomMargin = 1
+	// The share of the width, in percent, the Rank table keeps when the
+	// preview is shown next to it.
+	splitRankPercent = 60
 )
 
-var baseStyle = lipgloss.NewStyle().
-	BorderStyle(lipgloss.RoundedBorder()).
-	BorderForeground(lipgloss.Color("240")).
-	Padding(0, 1)
+// media is what the Rank and Detail tabs are browsing.
+type media int
+
+const (
+	mediaAnime media = iota
+	mediaManga
+)
+
+func (md media) String() string {
+	if md == mediaManga {
+		return "manga"
+	}
+	return "anime"
+}
+
+// page is what every tab implements, only the active one is focused.
+type page interface {
+	tea.Model
+	Focus()
+	Blur()
+}
 
 type Main struct {
+	*common
+
 	// General
 	width        int
 	height       int
 	contentWidth int
 	err          error
+	altScreen    bool
 
-	// Menubar
+	// Menubar, Rank and Detail show whichever media is being browsed
 	menubar []string
 	cursor  int
+	media   media
+
+	// Rank Page, with the detail preview on wide terminals
+	rank       *Rank
+	preview    *Preview
+	splitWidth int
+	split      bool
 
-	// Rank Page
-	rank *Rank
+	// Manga Rank Page
+	mangaRank *MangaRank
 
 	// Detail Page
-	detail *Detail
+	detail      *Detail
+	mangaDetail *MangaDetail
+
+	// Search Page
+	search *Search
+
+	// Bookmarks Page
+	bookmarks *Bookmarks
+
+	// Schedule Page
+	schedule *Schedule
+
+	// Compare Page, hidden from the menubar until an anime is marked
+	compare *Compare
+
+	// Franchise Page, hidden from the menubar until a franchise is opened
+	franchise *Franchise
+
+	// Logs Page, hidden from the menubar until it's opened
+	logs *Logs
 
-	client *url.Client
+	// Status bar for notifications and recoverable errors
+	status *StatusBar
+
+	// Keys
+	help     help.Model
+	showHelp bool
+
+	// Themes, the active one is shared with every page through common.theme
+	renderer     *lipgloss.Renderer
+	palettes     []style.Palette
+	paletteIndex int
+	noColor      bool
 }
 
-func New() Main {
-	menubar := []string{"Rank", "Detail", "Search"}
+func New(b *bookmark.Store, lg *logger.Logger, cfg config.Config, palettes []style.Palette, opts ...Option) (Main, error) {
+	menubar := []string{"Rank", "Detail", "Search", "Bookmarks", "Schedule"}
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	keys := DefaultKeyMaps()
+	if err := keys.Override(cfg.Keys); err != nil {
+		return Main{}, err
+	}
+	if o.remote {
+		// SIGTSTP would stop the whole server, not the session
+		keys.Global.Suspend.SetEnabled(false)
+	}
+
+	if len(palettes) == 0 {
+		palettes = []style.Palette{style.Dark}
+	}
+	renderer := o.renderer
+	// a terminal without colors, or NO_COLOR in its environment, gets the Ascii
+	// profile, which makes lipgloss drop every attribute and not only colors.
+	// The colorless theme already leaves colors out, so keep bold, underline
+	// and reverse.
+	noColor := cfg.NoColor || renderer.ColorProfile() == termenv.Ascii
+	if noColor {
+		renderer.SetColorProfile(termenv.ANSI)
+	}
+	paletteIndex := style.FindPalette(palettes, cfg.Theme)
+	theme := style.NewTheme(renderer, palettes[paletteIndex], noColor)
+
+	client := o.client
+	if client == nil {
+		client = url.NewClient()
+		client.SetLogger(lg)
+	}
 
-	c := url.NewClient()
-	r := NewRank(c)
-	d := NewDetail(c)
+	c := &common{
+		client:  client,
+		store:   b,
+		log:     lg,
+		zone:    zone.New(),
+		keyMaps: keys,
+		theme:   &theme,
+
+		clipboard: o.clipboard,
+		getenv:    o.getenv,
+		remote:    o.remote,
+	}
 
-	return Main{
-		rank:    r,
-		menubar: menubar,
-		cursor:  0,
-		detail:  d,
-		client:  c,
+	m := Main{
+		common:       c,
+		rank:         NewRank(c, cfg.RankColumns),
+		preview:      NewPreview(c),
+		splitWidth:   cfg.SplitWidth,
+		menubar:      menubar,
+		cursor:       0,
+		mangaRank:    NewMangaRank(c),
+		detail:       NewDetail(c),
+		mangaDetail:  NewMangaDetail(c),
+		bookmarks:    NewBookmarks(c),
+		search:       NewSearch(c),
+		schedule:     NewSchedule(c),
+		compare:      NewCompare(c),
+		franchise:    NewFranchise(c),
+		logs:         NewLogs(c),
+		status:       NewStatusBar(c),
+		help:         help.New(),
+		renderer:     renderer,
+		palettes:     palettes,
+		paletteIndex: paletteIndex,
+		noColor:      noColor,
+		altScreen:    cfg.AltScreen,
 	}
+	m.applyTheme()
+	return m, nil
+}
+
+// applyTheme restyles the components owned by Main, the pages restyle
+// themselves when they receive a message.ThemeMsg.
+func (m *Main) applyTheme() {
+	m.help.Styles.ShortKey = m.theme.HelpKey
+	m.help.Styles.ShortDesc = m.theme.HelpDesc
+	m.help.Styles.ShortSeparator = m.theme.HelpDesc
+	m.help.Styles.FullKey = m.theme.HelpKey
+	m.help.Styles.FullDesc = m.theme.HelpDesc
+	m.help.Styles.FullSeparator = m.theme.HelpDesc
+	m.help.Styles.Ellipsis = m.theme.HelpDesc
+}
+
+// nextTheme switches to the next theme and lets every page know.
+func (m *Main) nextTheme() tea.Cmd {
+	m.paletteIndex = (m.paletteIndex + 1) % len(m.palettes)
+	*m.theme = style.NewTheme(m.renderer, m.palettes[m.paletteIndex], m.noColor)
+	m.applyTheme()
+
+	var cmds []tea.Cmd
+	for _, p := range m.pages() {
+		_, cmd := p.Update(message.ThemeMsg{})
+		cmds = append(cmds, cmd)
+	}
+
+	_, cmd := m.status.Update(message.NotifyMsg{Severity: message.SeverityInfo, Text: "Theme: " + m.theme.Name})
+	cmds = append(cmds, cmd)
+
+	return tea.Batch(cmds...)
 }
 
 func (m Main) Init() tea.Cmd {
-	return m.rank.initialRequest
+	return tea.Batch(m.rank.initialRequest, m.schedule.request, m.detail.Init())
 }
 
 func (m Main) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// If we're in an error state, the only thing we care about is the key press
-	// to dismiss the error.
+	// If we're in a fatal error state, the only key press we care about is the one
+	// dismissing the error. What pages started in the background still reaches
+	// them, the template check and the toast timers would stop for good otherwise.
 	if m.err != nil {
-		if msg, ok := msg.(tea.KeyMsg); ok {
-			switch msg.String() {
-			case "enter", "esc", "q":
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch {
+			case key.Matches(msg, m.keyMaps.Input.Accept, m.keyMaps.Input.Cancel):
 				m.err = nil // Clear the error
+			case key.Matches(msg, m.keyMaps.Global.Suspend):
+				return m, tea.Suspend
+			case key.Matches(msg, m.keyMaps.Global.Quit):
+				return m, tea.Quit
 			}
+			return m, nil
+		case tea.WindowSizeMsg, tea.ResumeMsg:
+			// keep the layout behind the error in sync with the terminal
+		case pictureMsg, templateFileMsg, franchiseMsg, compareLoadedMsg, scheduleMsg,
+			searchMsg, previewTickMsg, previewMsg, toastExpiredMsg:
+		default:
+			return m, nil
 		}
-		return m, nil
 	}
 
 	// tea.Cmd this is used if you want to set new value to the current UI
@@ -90,100 +269,381 @@ func (m Main) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		if !m.altScreen {
+			m.height = max(0, msg.Height-inlineBottomMargin)
+		}
 
 		// Calculate the final content width
 		// by subtracting the horizontal space taken by the baseStyle's border and padding.
-		horizontalMargin := baseStyle.GetHorizontalFrameSize()
+		horizontalMargin := m.theme.Frame.GetHorizontalFrameSize()
 		m.contentWidth = m.width - horizontalMargin
 
 		// Calculate the height available for child models.
-		borderHeight := baseStyle.GetVerticalFrameSize()
-		contentHeight := m.height - mainHeaderHeight - mainVerticalPadding - borderHeight
+		borderHeight := m.theme.Frame.GetVerticalFrameSize()
+		contentHeight := m.height - mainHeaderHeight - mainVerticalPadding - mainFooterHeight - borderHeight
+		m.help.Width = m.contentWidth
 
 		// Create the message for child models with the correct dimensions.
 		childMsg := tea.WindowSizeMsg{Width: m.contentWidth, Height: contentHeight}
 
-		// Update the child models with the new dimensions *immediately* and collect their commands
-		rank, cmd := m.rank.Update(childMsg)
-		if r, ok := rank.(*Rank); ok {
-			m.rank = r
+		// On wide terminals the Rank page shares its width with the preview
+		m.split = m.splitWidth > 0 && m.contentWidth >= m.splitWidth
+		rankMsg := childMsg
+		if m.split {
+			rankMsg.Width = m.contentWidth * splitRankPercent / 100
+			m.preview.Update(tea.WindowSizeMsg{Width: m.contentWidth - rankMsg.Width, Height: contentHeight})
 		}
-		cmds = append(cmds, cmd)
 
-		detail, cmd := m.detail.Update(childMsg)
-		if d, ok := detail.(*Detail); ok {
-			m.detail = d
+		// Update the child models with the new dimensions *immediately* and collect their commands
+		for _, p := range m.pages() {
+			msg := childMsg
+			if p == page(m.rank) {
+				msg = rankMsg
+			}
+			_, cmd := p.Update(msg)
+			cmds = append(cmds, cmd)
 		}
-		cmds = append(cmds, cmd)
+		m.status.Update(tea.WindowSizeMsg{Width: m.contentWidth, Height: 1})
 
 		return m, tea.Batch(cmds...)
 
 	case message.ErrMsg:
-		m.err = msg.Err                              // Set the error
-		log.Printf("An error occurred: %v", msg.Err) // Log the technical details
-		return m, nil
+		m.log.Errorf("%v", msg.Err) // Log the technical details
+		if msg.Fatal {
+			m.err = msg.Err // Set the error
+			return m, nil
+		}
+		_, cmd := m.status.Update(msg)
+		return m, cmd
+
+	// releasing the terminal on suspend turns the mouse off and bubbletea
+	// doesn't turn it back on, the size is checked again by bubbletea itself
+	case tea.ResumeMsg:
+		return m, tea.EnableMouseCellMotion
+
+	case message.NotifyMsg:
+		if msg.Severity == message.SeverityWarning {
+			m.log.Warnf("%s", msg.Text)
+		} else {
+			m.log.Infof("%s", msg.Text)
+		}
+		_, cmd := m.status.Update(msg)
+		return m, cmd
+
+	case message.CompareMsg:
+		marked, cmd := m.compare.Toggle(msg.ID)
+		m.addTab("Compare")
+
+		text := fmt.Sprintf("Marked for comparison (%d)", m.compare.Len())
+		if !marked {
+			text = fmt.Sprintf("Unmarked from comparison (%d)", m.compare.Len())
+		}
+		_, notify := m.status.Update(message.NotifyMsg{Severity: message.SeverityInfo, Text: text})
+		return m, tea.Batch(cmd, notify)
+
+	case message.FranchiseMsg:
+		m.cursor = m.addTab("Franchise")
+
+	case pictureMsg, templateFileMsg:
+		_, cmd := m.detail.Update(msg)
+		return m, cmd
+
+	case franchiseMsg:
+		_, cmd := m.franchise.Update(msg)
+		return m, cmd
+
+	case compareLoadedMsg:
+		_, cmd := m.compare.Update(msg)
+		return m, cmd
+
+	case scheduleMsg:
+		_, cmd := m.schedule.Update(msg)
+		return m, cmd
+
+	case searchMsg:
+		_, cmd := m.search.Update(msg)
+		return m, cmd
+
+	case previewTickMsg, previewMsg:
+		_, cmd := m.preview.Update(msg)
+		return m, cmd
+
+	case toastExpiredMsg:
+		_, cmd := m.status.Update(msg)
+		return m, cmd
+
+	// the bookmarks page always needs to know, no matter which page starred the anime
+	case message.BookmarkMsg:
+		bookmarks, cmd := m.bookmarks.Update(msg)
+		if b, ok := bookmarks.(*Bookmarks); ok {
+			m.bookmarks = b
+		}
+
+		text := "Added to bookmarks"
+		if !msg.Bookmarked {
+			text = "Removed from bookmarks"
+		}
+		_, notify := m.status.Update(message.NotifyMsg{Severity: message.SeverityInfo, Text: text})
+		return m, tea.Batch(cmd, notify)
+
+	case tea.MouseMsg:
+		if leftClick(msg) {
+			for i := range m.menubar {
+				if m.zone.Get(zoneTab(i)).InBounds(msg) {
+					m.cursor = i
+					return m, nil
+				}
+			}
+		}
 
 	// if key press
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "right", "l":
+		// let the page have every key while it's capturing text input,
+		// ctrl+c and suspend are the exceptions so there's always a way out
+		if m.editing() {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			if key.Matches(msg, m.keyMaps.Global.Suspend) {
+				return m, tea.Suspend
+			}
+			break
+		}
+
+		if m.showHelp {
+			switch {
+			case key.Matches(msg, m.keyMaps.Global.Help, m.keyMaps.Input.Cancel):
+				m.showHelp = false
+			case key.Matches(msg, m.keyMaps.Global.Suspend):
+				return m, tea.Suspend
+			case key.Matches(msg, m.keyMaps.Global.Quit):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMaps.Global.NextTab):
 			if m.cursor < len(m.menubar)-1 {
 				m.cursor++
 			} else {
 				m.cursor = 0
 			}
-		case "left", "h":
+		case key.Matches(msg, m.keyMaps.Global.PrevTab):
 			if m.cursor > 0 {
 				m.cursor--
 			} else {
 				m.cursor = len(m.menubar) - 1
 			}
-		case "ctrcl+c", "q":
+		case key.Matches(msg, m.keyMaps.Global.Help):
+			m.showHelp = true
+			return m, nil
+		case key.Matches(msg, m.keyMaps.Global.Theme):
+			return m, m.nextTheme()
+		case key.Matches(msg, m.keyMaps.Global.Retry) && m.status.CanRetry():
+			return m, m.status.Retry()
+		case key.Matches(msg, m.keyMaps.Global.Compare):
+			m.cursor = m.addTab("Compare")
+			return m, nil
+		case key.Matches(msg, m.keyMaps.Global.Logs):
+			m.cursor = m.addTab("Logs")
+			return m, nil
+		case key.Matches(msg, m.keyMaps.Global.Media):
+			return m, m.switchMedia()
+		case key.Matches(msg, m.keyMaps.Global.Suspend):
+			return m, tea.Suspend
+		case key.Matches(msg, m.keyMaps.Global.Quit):
 			return m, tea.Quit
 		}
 
 	// The main update switch at the bottom will handle passing subsequent messages.
 	case message.RankMsg:
 		m.cursor = 0
-		m.rank.Focus()
+		m.media = mediaAnime
 
 	case message.DetailMsg:
 		m.cursor = 1
-		m.detail.Focus()
+		m.media = mediaAnime
+
+	case message.MangaDetailMsg:
+		m.cursor = 1
+		m.media = mediaManga
+
+	// the ranking arrives whichever tab is open by then
+	case *entity.MangaData:
+		_, cmd := m.mangaRank.Update(msg)
+		return m, cmd
 	}
 
 	// Delegate messages down to the active child model.
-	var cmd tea.Cmd
+	active := m.activePage()
+	for _, p := range m.pages() {
+		if p != active {
+			p.Blur()
+		}
+	}
+	if active == nil {
+		return m, tea.Batch(cmds...)
+	}
+	active.Focus()
+	_, cmd := active.Update(msg)
+	cmds = append(cmds, cmd)
+
+	if active == page(m.rank) {
+		cmds = append(cmds, m.rank.prefetch())
+		// follow the cursor, whatever moved it
+		if m.split {
+			if a, err := m.rank.selectedAnime(); err == nil {
+				cmds = append(cmds, m.preview.Select(*a))
+			}
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// pages lists every page, whether its tab is shown or not.
+func (m Main) pages() []page {
+	return []page{m.rank, m.mangaRank, m.detail, m.mangaDetail, m.search, m.bookmarks, m.schedule, m.compare, m.franchise, m.logs}
+}
+
+// activePage returns the page behind the selected tab.
+func (m Main) activePage() page {
 	switch m.menubar[m.cursor] {
 	case "Rank":
-		m.rank.Focus()
-		m.detail.Blur()
-		rank, newCmd := m.rank.Update(msg)
-		if r, ok := rank.(*Rank); ok {
-			m.rank = r
+		if m.media == mediaManga {
+			return m.mangaRank
 		}
-		cmd = newCmd
+		return m.rank
 	case "Detail":
-		m.rank.Blur()
-		m.detail.Focus()
-		detail, newCmd := m.detail.Update(msg)
-		if d, ok := detail.(*Detail); ok {
-			m.detail = d
+		if m.media == mediaManga {
+			return m.mangaDetail
 		}
-		cmd = newCmd
+		return m.detail
+	case "Search":
+		return m.search
+	case "Bookmarks":
+		return m.bookmarks
+	case "Schedule":
+		return m.schedule
+	case "Compare":
+		return m.compare
+	case "Franchise":
+		return m.franchise
+	case "Logs":
+		return m.logs
 	}
-	cmds = append(cmds, cmd)
-	return m, tea.Batch(cmds...)
+	return nil
+}
+
+// switchMedia flips Rank and Detail between anime and manga, the manga
+// ranking is only fetched the first time it's needed.
+func (m *Main) switchMedia() tea.Cmd {
+	var cmd tea.Cmd
+	if m.media == mediaAnime {
+		m.media = mediaManga
+		cmd = m.mangaRank.Request()
+	} else {
+		m.media = mediaAnime
+	}
+
+	_, notify := m.status.Update(message.NotifyMsg{Severity: message.SeverityInfo, Text: "Browsing " + m.media.String()})
+	return tea.Batch(cmd, notify)
+}
+
+// addTab adds one of the hidden tabs the first time it's needed and
+// returns its index.
+func (m *Main) addTab(name string) int {
+	i := slices.Index(m.menubar, name)
+	if i < 0 {
+		m.menubar = append(m.menubar, name)
+		i = len(m.menubar) - 1
+	}
+	return i
+}
+
+// editing reports whether the active page is capturing text input.
+func (m Main) editing() bool {
+	switch m.activePage() {
+	case page(m.rank):
+		return m.rank.Editing()
+	case page(m.search):
+		return m.search.Editing()
+	case page(m.bookmarks):
+		return m.bookmarks.Editing()
+	}
+	return false
+}
+
+// pageKeyMap returns the keymap of the active page, or the input keymap
+// while the page is capturing text.
+func (m Main) pageKeyMap() help.KeyMap {
+	switch m.activePage() {
+	case page(m.rank):
+		return m.rank.KeyMap()
+	case page(m.mangaRank):
+		return m.mangaRank.KeyMap()
+	case page(m.detail):
+		return m.detail.KeyMap()
+	case page(m.mangaDetail):
+		return m.mangaDetail.KeyMap()
+	case page(m.search):
+		return m.search.KeyMap()
+	case page(m.bookmarks):
+		return m.bookmarks.KeyMap()
+	case page(m.schedule):
+		return m.keyMaps.Schedule
+	case page(m.compare):
+		return m.keyMaps.Compare
+	case page(m.franchise):
+		return m.keyMaps.Franchise
+	case page(m.logs):
+		return m.keyMaps.Logs
+	}
+	return nil
+}
+
+// helpView renders the short help of the active page followed by the global keys.
+func (m Main) helpView() string {
+	var bindings []key.Binding
+	if km := m.pageKeyMap(); km != nil {
+		bindings = append(bindings, km.ShortHelp()...)
+	}
+	if m.status.CanRetry() {
+		bindings = append(bindings, m.keyMaps.Global.Retry)
+	}
+	bindings = append(bindings, m.keyMaps.Global.ShortHelp()...)
+	return m.help.ShortHelpView(bindings)
+}
+
+// helpOverlay renders every binding of the active page and the global keys in a box.
+func (m Main) helpOverlay(width, height int) string {
+	var groups [][]key.Binding
+	if km := m.pageKeyMap(); km != nil {
+		groups = append(groups, km.FullHelp()...)
+	}
+	groups = append(groups, m.keyMaps.Global.FullHelp()...)
+
+	box := m.theme.HelpBox.
+		Render(lipgloss.JoinVertical(lipgloss.Left,
+			m.theme.DetailTitle.Render("Keybindings"),
+			"",
+			m.help.FullHelpView(groups),
+		))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
 }
 
 func (m Main) generateMenubar() string {
 	var menu []string
 
 	for i, v := range m.menubar {
+		if m.media == mediaManga && (v == "Rank" || v == "Detail") {
+			v += " · " + m.media.String()
+		}
 		if i == m.cursor {
-			menu = append(menu, style.ActiveTab.Render(v))
+			menu = append(menu, m.zone.Mark(zoneTab(i), m.theme.ActiveTab.Render(v)))
 		} else {
-			menu = append(menu, style.Tab.Render(v))
+			menu = append(menu, m.zone.Mark(zoneTab(i), m.theme.Tab.Render(v)))
 		}
 	}
 
@@ -191,7 +651,7 @@ func (m Main) generateMenubar() string {
 		lipgloss.Top,
 		menu...,
 	)
-	gap := style.TabGap.Render(strings.Repeat(" ", max(0, m.contentWidth-lipgloss.Width(menubar))))
+	gap := m.theme.TabGap.Render(strings.Repeat(" ", max(0, m.contentWidth-lipgloss.Width(menubar))))
 	return lipgloss.JoinHorizontal(lipgloss.Bottom, menubar, gap)
 }
 
@@ -202,14 +662,19 @@ func (m Main) View() string {
 
 	// The title should be rendered inside a container that can be constrained.
 	// We'll set the width of the title's container to the contentWidth.
-	title := lipgloss.NewStyle().Width(m.contentWidth).Render(style.Title.Render())
+	title := m.renderer.NewStyle().Width(m.contentWidth).Render(m.theme.Title.Render())
 
 	var body string
-	switch m.menubar[m.cursor] {
-	case "Rank":
-		body = m.rank.View()
-	case "Detail":
-		body = m.detail.View()
+	if active := m.activePage(); active != nil {
+		body = active.View()
+		// the preview only knows about anime
+		if active == page(m.rank) && m.split {
+			body = lipgloss.JoinHorizontal(lipgloss.Top, body, m.preview.View())
+		}
+	}
+
+	if m.showHelp {
+		body = m.helpOverlay(m.contentWidth, lipgloss.Height(body))
 	}
 
 	mainContent := lipgloss.JoinVertical(
@@ -217,32 +682,20 @@ func (m Main) View() string {
 		title,
 		m.generateMenubar(),
 		body,
+		m.status.View(),
+		m.helpView(),
 	)
 
-	return baseStyle.Render(mainContent)
+	// Scan strips the zone markers and records where every zone ended up on screen
+	return m.zone.Scan(m.theme.Frame.Render(mainContent))
 }
 
 func (m Main) errorView(width, height int) string {
-	errorHeader := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F1F1F1")).
-		Background(lipgloss.Color("#FF5F87")).
-		Bold(true).
-		Padding(0, 1).
-		Render(" Oh No! An Error Occurred ")
-
-	errorBody := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252")).
-		Padding(1).
-		Render(m.err.Error())
-
-	helpText := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
-		Render("Press Enter or Esc to continue...")
-
-	errorBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#FF5F87")).
-		Render(lipgloss.JoinVertical(lipgloss.Center, errorHeader, errorBody, helpText))
+	errorHeader := m.theme.ErrorTitle.Render(" Oh No! An Error Occurred ")
+	errorBody := m.theme.ErrorBody.Render(m.err.Error())
+	helpText := m.theme.Muted.Render("Press Enter or Esc to continue...")
+
+	errorBox := m.theme.ErrorBox.Render(lipgloss.JoinVertical(lipgloss.Center, errorHeader, errorBody, helpText))
 
 	// Place the error box in the center of the screen.
 	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, errorBox)
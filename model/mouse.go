@@ -0,0 +1,47 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickInterval is how close two clicks on the same row need to be
+// to count as a double click, the terminal doesn't report those for us.
+const doubleClickInterval = 400 * time.Millisecond
+
+// Zone IDs used for mouse hit-testing, every Main has its own zone manager
+// so they only need to be unique within a program.
+const (
+	zoneRankTable  = "rank-table"
+	zoneRankCursor = "rank-cursor"
+)
+
+func zoneTab(i int) string { return fmt.Sprintf("tab-%d", i) }
+
+func zoneAnimeLink(id int) string { return fmt.Sprintf("anime-link-%d", id) }
+
+func zoneMangaLink(id int) string { return fmt.Sprintf("manga-link-%d", id) }
+
+func leftClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+func wheelUp(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonWheelUp
+}
+
+func wheelDown(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonWheelDown
+}
+
+type click struct {
+	row int
+	at  time.Time
+}
+
+// isDouble reports whether a click on row right now follows c closely enough.
+func (c click) isDouble(row int, now time.Time) bool {
+	return c.row == row && now.Sub(c.at) <= doubleClickInterval
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/url"
+)
+
+// Option customizes a Main, the defaults suit a program running in the
+// local terminal.
+type Option func(*options)
+
+type options struct {
+	client    *url.Client
+	renderer  *lipgloss.Renderer
+	clipboard io.Writer
+	getenv    func(string) string
+	remote    bool
+}
+
+func defaultOptions() options {
+	return options{
+		renderer: lipgloss.DefaultRenderer(),
+		// stdout belongs to the renderer
+		clipboard: os.Stderr,
+		getenv:    os.Getenv,
+	}
+}
+
+// WithClient shares the client, and with it the detail cache and the rate
+// limit, with other programs. Its logger is left as it is.
+func WithClient(c *url.Client) Option {
+	return func(o *options) { o.client = c }
+}
+
+// WithRenderer styles the app for another terminal than the one the
+// process runs in.
+func WithRenderer(r *lipgloss.Renderer) Option {
+	return func(o *options) { o.renderer = r }
+}
+
+// WithRemote is for a program shown over SSH. Clipboard sequences go to out
+// and env, the session's environment, replaces the process one. What would
+// happen on the server rather than in front of the user, opening the
+// browser or a picture, saving pictures and suspending, is turned off.
+func WithRemote(out io.Writer, env []string) Option {
+	return func(o *options) {
+		o.clipboard = out
+		o.getenv = func(key string) string {
+			for _, kv := range env {
+				if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+					return v
+				}
+			}
+			return ""
+		}
+		o.remote = true
+	}
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"strings"
+
+	// the formats MAL serves pictures in
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// samplesPerPixel bounds how many source pixels are averaged into each
+// drawn pixel along each axis, big pictures don't need every one of them.
+const samplesPerPixel = 4
+
+type pictureMsg struct {
+	link string
+	data []byte
+	err  error
+}
+
+// picture is a downloaded picture, img is nil when it couldn't be decoded.
+type picture struct {
+	data []byte
+	img  image.Image
+	err  error
+}
+
+func decodePicture(msg pictureMsg) *picture {
+	if msg.err != nil {
+		return &picture{err: msg.err}
+	}
+	img, _, err := image.Decode(bytes.NewReader(msg.data))
+	if err != nil {
+		return &picture{data: msg.data, err: fmt.Errorf("can't decode the picture: %w", err)}
+	}
+	return &picture{data: msg.data, img: img}
+}
+
+// halfBlocks draws img in at most width cells by height lines, keeping its
+// aspect ratio. Every cell is an upper half block holding two pixels, the
+// top one as foreground and the bottom one as background, which makes the
+// pixels roughly square.
+func halfBlocks(img image.Image, width, height int, st lipgloss.Style) string {
+	b := img.Bounds()
+	if b.Empty() || width <= 0 || height <= 0 {
+		return ""
+	}
+
+	scale := min(float64(width)/float64(b.Dx()), float64(height*2)/float64(b.Dy()))
+	w := max(1, int(float64(b.Dx())*scale))
+	h := max(2, int(float64(b.Dy())*scale)/2*2)
+
+	lines := make([]string, 0, h/2)
+	var line strings.Builder
+	for y := 0; y < h; y += 2 {
+		line.Reset()
+		for x := range w {
+			line.WriteString(st.
+				Foreground(samplePixel(img, x, y, w, h)).
+				Background(samplePixel(img, x, y+1, w, h)).
+				Render("▀"))
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// samplePixel averages the part of img that ends up in pixel x, y of
+// the w by h drawing, so shrinking a picture doesn't turn it into noise.
+func samplePixel(img image.Image, x, y, w, h int) lipgloss.Color {
+	b := img.Bounds()
+	x0, x1 := b.Min.X+x*b.Dx()/w, b.Min.X+(x+1)*b.Dx()/w
+	y0, y1 := b.Min.Y+y*b.Dy()/h, b.Min.Y+(y+1)*b.Dy()/h
+	x1, y1 = max(x1, x0+1), max(y1, y0+1)
+	stepX := max(1, (x1-x0)/samplesPerPixel)
+	stepY := max(1, (y1-y0)/samplesPerPixel)
+
+	var r, g, bl, n uint32
+	for sy := y0; sy < y1; sy += stepY {
+		for sx := x0; sx < x1; sx += stepX {
+			cr, cg, cb, _ := img.At(sx, sy).RGBA()
+			r, g, bl, n = r+cr>>8, g+cg>>8, bl+cb>>8, n+1
+		}
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r/n, g/n, bl/n))
+}
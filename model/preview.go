@@ -0,0 +1,159 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/entity"
+)
+
+// previewDebounce is how long the Rank cursor has to rest on a row before
+// its detail is fetched, so scrolling through the table doesn't fire a
+// request for every row passed on the way.
+const previewDebounce = 300 * time.Millisecond
+
+// previewTickMsg fires once the debounce is over, it's stale if another
+// row was selected in the meantime.
+type previewTickMsg struct {
+	seq int
+	id  int
+}
+
+type previewMsg struct {
+	id     int
+	detail *entity.Detail
+	err    error
+}
+
+// Preview is the compact detail shown next to the Rank table on wide terminals.
+type Preview struct {
+	*common
+
+	anime   *entity.AnimeRank
+	detail  *entity.Detail
+	err     error
+	loading bool
+	seq     int
+	width   int
+	height  int
+}
+
+func NewPreview(c *common) *Preview {
+	return &Preview{common: c}
+}
+
+func (p Preview) Init() tea.Cmd { return nil }
+
+// Select shows what the rank row already knows right away and schedules
+// the fetch of the rest.
+func (p *Preview) Select(a entity.AnimeRank) tea.Cmd {
+	if p.anime != nil && p.anime.Anime.ID == a.Anime.ID {
+		return nil
+	}
+
+	p.anime = &a
+	p.detail = nil
+	p.err = nil
+	p.loading = true
+	p.seq++
+
+	seq, id := p.seq, a.Anime.ID
+	return tea.Tick(previewDebounce, func(time.Time) tea.Msg {
+		return previewTickMsg{seq: seq, id: id}
+	})
+}
+
+func (p *Preview) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+
+	case previewTickMsg:
+		if msg.seq != p.seq {
+			return p, nil
+		}
+		client := p.client
+		return p, func() tea.Msg {
+			detail, err := client.AnimeDetail(msg.id)
+			return previewMsg{id: msg.id, detail: detail, err: err}
+		}
+
+	case previewMsg:
+		if p.anime == nil || p.anime.Anime.ID != msg.id {
+			return p, nil
+		}
+		p.loading = false
+		if msg.err != nil {
+			// the full Detail page reports errors, a preview only says it failed
+			p.err = msg.err
+			p.log.Warnf("failed to preview ID %d: %v", msg.id, msg.err)
+			return p, nil
+		}
+		p.detail = msg.detail
+	}
+
+	return p, nil
+}
+
+func (p Preview) View() string {
+	box := p.theme.Base.
+		Width(max(0, p.width-1)).
+		Height(p.height).
+		MaxHeight(p.height).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(p.theme.Separator.GetForeground())
+
+	if p.anime == nil {
+		return box.Render(p.theme.Muted.Render("Nothing selected."))
+	}
+
+	width := max(0, p.width-box.GetHorizontalFrameSize())
+	wrap := lipgloss.NewStyle().Width(width)
+
+	a := *p.anime
+	stats := []string{
+		"Score " + rankColumns["score"].value(a),
+		"Rank #" + rankColumns["rank"].value(a),
+		humanizeCount(a.Anime.Members) + " members",
+	}
+	var info []string
+	for _, v := range []string{rankColumns["media_type"].value(a), rankColumns["episodes"].value(a) + " eps", rankColumns["season"].value(a)} {
+		if v != "" {
+			info = append(info, v)
+		}
+	}
+
+	lines := []string{
+		wrap.Render(p.theme.DetailTitle.Render(animeTitle(a.Anime))),
+		p.theme.Base.Render(strings.Join(stats, " • ")),
+		p.theme.Muted.Render(strings.Join(info, " • ")),
+		"",
+	}
+
+	switch {
+	case p.loading:
+		lines = append(lines, p.theme.Muted.Render("Loading..."))
+	case p.err != nil:
+		lines = append(lines, p.theme.StatusError.Render(fmt.Sprintf("Couldn't load the detail, press %s to open it.", p.keyMaps.Rank.Open.Help().Key)))
+	case p.detail != nil:
+		if p.detail.Status != "" {
+			lines = append(lines, "Status: "+p.detail.Status.String())
+		}
+		if genres := joinNames(p.detail.Genres); genres != "" {
+			lines = append(lines, wrap.Render("Genres: "+genres))
+		}
+		if studios := joinNames(p.detail.Studios); studios != "" {
+			lines = append(lines, wrap.Render("Studios: "+studios))
+		}
+		if p.detail.Synopsis != "" {
+			lines = append(lines, "", wrap.Render(p.detail.Synopsis))
+		}
+	}
+
+	return box.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -1,12 +1,18 @@
 package model
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
-	"strconv"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/izzanzahrial/tui/entity"
@@ -14,50 +20,146 @@ import (
 	"github.com/izzanzahrial/tui/url"
 )
 
+// prefetchRadius is how many rows above and below the cursor get their
+// detail prefetched.
+const prefetchRadius = 2
+
+type sortField int
+
+const (
+	sortRank sortField = iota
+	sortTitle
+	sortJapaneseTitle
+	sortScore
+	sortPopularity
+	sortMembers
+	sortEpisodes
+	sortSeason
+	sortMediaType
+	sortFieldCount
+)
+
+var sortFieldNames = map[sortField]string{
+	sortRank:          "rank",
+	sortTitle:         "title",
+	sortJapaneseTitle: "japanese title",
+	sortScore:         "score",
+	sortPopularity:    "popularity",
+	sortMembers:       "members",
+	sortEpisodes:      "episodes",
+	sortSeason:        "season",
+	sortMediaType:     "type",
+}
+
+var seasonOrder = map[string]int{"winter": 0, "spring": 1, "summer": 2, "fall": 3}
+
+// compare orders a before b by the field in ascending order.
+func (f sortField) compare(a, b entity.AnimeRank) int {
+	switch f {
+	case sortTitle:
+		return cmp.Compare(strings.ToLower(animeTitle(a.Anime)), strings.ToLower(animeTitle(b.Anime)))
+	case sortJapaneseTitle:
+		return cmp.Compare(a.Anime.AlternativeTitle.JpnTitle, b.Anime.AlternativeTitle.JpnTitle)
+	case sortScore:
+		return cmp.Compare(a.Anime.Mean, b.Anime.Mean)
+	case sortPopularity:
+		return cmp.Compare(a.Anime.Popularity, b.Anime.Popularity)
+	case sortMembers:
+		return cmp.Compare(a.Anime.Members, b.Anime.Members)
+	case sortEpisodes:
+		return cmp.Compare(a.Anime.Episodes, b.Anime.Episodes)
+	case sortSeason:
+		return cmp.Or(
+			cmp.Compare(a.Anime.StartSeason.Year, b.Anime.StartSeason.Year),
+			cmp.Compare(seasonOrder[a.Anime.StartSeason.Season], seasonOrder[b.Anime.StartSeason.Season]),
+		)
+	case sortMediaType:
+		return cmp.Compare(a.Anime.MediaType, b.Anime.MediaType)
+	default:
+		return cmp.Compare(a.Rank.Rank, b.Rank.Rank)
+	}
+}
+
 type Rank struct {
+	*common
+
 	anime     *entity.Data
-	animeMap  map[int]*entity.AnimeRank
+	visible   []entity.AnimeRank
 	isLoading bool
 	spinner   spinner.Model
 	table     *table.Model
-	client    *url.Client
+
+	// columns are the configured columns, shown are the ones that fit the current width
+	columns []rankColumn
+	shown   []rankColumn
+
+	// sorting and filtering
+	sortBy    sortField
+	sortDesc  bool
+	filter    textinput.Model
+	filtering bool
+
+	// mouse
+	lastClick click
+
+	// prefetched is the anime the last prefetch was centered on
+	prefetched int
+
+	keys      RankKeyMap
+	inputKeys InputKeyMap
 }
 
-func NewRank(c *url.Client) *Rank {
-	sp := spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("205"))))
+func NewRank(c *common, columnNames []string) *Rank {
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
 
-	columns := []table.Column{
-		{Title: "Rank", Width: 4},
-		{Title: "Title", Width: 40},
-		{Title: "Japanese Title", Width: 40},
-	}
+	columns := newRankColumns(columnNames)
+	// a sensible width until the first tea.WindowSizeMsg arrives
+	shown := layoutColumns(columns, 100)
 
 	t := table.New(
-		table.WithColumns(columns),
+		table.WithColumns(tableColumns(shown)),
 		table.WithFocused(true), // Start focused by default
 		table.WithHeight(10),    // Initial height, will be resized
+		table.WithKeyMap(c.keyMaps.Rank.KeyMap),
 	)
 
-	s := table.DefaultStyles()
-	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true).Bold(false)
-	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(false)
-	t.SetStyles(s)
+	fi := textinput.New()
+	fi.Prompt = "/"
+	fi.Placeholder = "filter by title"
 
-	return &Rank{
+	r := &Rank{
+		common:    c,
 		anime:     &entity.Data{},
-		animeMap:  make(map[int]*entity.AnimeRank),
 		isLoading: true,
 		spinner:   sp,
 		table:     &t,
-		client:    c,
+		columns:   columns,
+		shown:     shown,
+		filter:    fi,
+		keys:      c.keyMaps.Rank,
+		inputKeys: c.keyMaps.Input,
 	}
+	r.applyTheme()
+	return r
+}
+
+// applyTheme restyles the components that keep a copy of their styles.
+func (r *Rank) applyTheme() {
+	r.spinner.Style = r.theme.Spinner
+	r.table.SetStyles(table.Styles{
+		Header: r.theme.TableHeader,
+		Cell:   r.theme.TableCell,
+		// the table doesn't expose its scroll offset, so we mark the selected row
+		// to know on which line of the screen the cursor is
+		Selected: r.theme.TableSelected.Transform(func(s string) string { return r.zone.Mark(zoneRankCursor, s) }),
+	})
 }
 
 // initialRequest fetches the first batch of data needed for the rank view
 func (r Rank) initialRequest() tea.Msg {
 	data, err := r.client.AnimeRank(0, nil, nil)
 	if err != nil {
-		return message.ErrMsg{Err: fmt.Errorf("failed to fetch anime ranks: %w", err)}
+		return message.ErrMsg{Err: fmt.Errorf("failed to fetch anime ranks: %w", err), Retry: r.initialRequest}
 	}
 
 	return data
@@ -79,62 +181,150 @@ func (r *Rank) Blur() {
 	r.table.Blur()
 }
 
+// Editing reports whether the filter prompt is capturing key presses.
+func (r *Rank) Editing() bool { return r.filtering }
+
+func (r *Rank) KeyMap() help.KeyMap {
+	if r.filtering {
+		return r.inputKeys
+	}
+	return r.keys
+}
+
 func (r *Rank) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		r.table.SetWidth(msg.Width)
-		r.table.SetHeight(msg.Height)
+		// clear the rows first, the table panics if a row has more cells than columns,
+		// that also resets the cursor so put it back afterwards
+		cursor := r.table.Cursor()
+		r.table.SetRows(nil)
+		// the table is drawn inside the frame, msg.Width is the width of both
+		width := msg.Width - r.theme.Frame.GetHorizontalFrameSize()
+		r.shown = layoutColumns(r.columns, width)
+		r.table.SetColumns(tableColumns(r.shown))
+		r.refresh()
+		r.table.SetCursor(min(max(0, cursor), max(0, len(r.visible)-1)))
+
+		r.table.SetWidth(width)
+		// leave one line for the sort and filter status
+		r.table.SetHeight(msg.Height - 1)
+		r.filter.Width = msg.Width - 2
+		return r, nil
+
+	case message.ThemeMsg:
+		r.applyTheme()
 		return r, nil
 
 	case *entity.Data:
 		r.anime = msg
-		for _, v := range r.anime.AnimeRank {
-			r.animeMap[v.Rank.Rank] = &v
+		r.refresh()
+		r.isLoading = false
+		return r, nil // No further command needed
+
+	case tea.MouseMsg:
+		if !r.table.Focused() || r.isLoading || r.filtering {
+			return r, nil
 		}
 
-		rows := make([]table.Row, len(r.anime.AnimeRank))
-		for i, anime := range r.anime.AnimeRank {
-			title := anime.Anime.AlternativeTitle.EngTitle
-			if title == "" {
-				title = anime.Anime.Title
-			}
-			rows[i] = table.Row{strconv.Itoa(anime.Rank.Rank), title, anime.Anime.AlternativeTitle.JpnTitle}
+		switch {
+		case wheelUp(msg):
+			r.table.MoveUp(1)
+		case wheelDown(msg):
+			r.table.MoveDown(1)
+		case leftClick(msg):
+			return r.click(msg)
 		}
-		r.table.SetRows(rows)
-		r.isLoading = false
-		return r, nil // No further command needed
+		return r, nil
 
 	case tea.KeyMsg:
+		if r.filtering {
+			return r.updateFilter(msg)
+		}
+
 		// Don't handle keys if we're not focused.
 		if !r.table.Focused() {
 			return r, nil
 		}
 
-		switch msg.String() {
-		case "enter", " ":
+		switch {
+		case key.Matches(msg, r.keys.Open):
 			if len(r.table.SelectedRow()) == 0 {
 				return r, nil
 			}
 
-			rankStr := r.table.SelectedRow()[0]
-			rankInt, err := strconv.Atoi(rankStr)
+			anime, err := r.selectedAnime()
 			if err != nil {
-				return r, func() tea.Msg {
-					return message.ErrMsg{Err: fmt.Errorf("error parsing rank [%s]: %w", rankStr, err)}
-				}
-			}
-
-			anime, ok := r.animeMap[rankInt]
-			if !ok {
-				return r, func() tea.Msg {
-					return message.ErrMsg{Err: errors.New("anime not found")}
-				}
+				return r, func() tea.Msg { return message.ErrMsg{Err: err} }
 			}
 
 			// Send message to switch to the detail view
 			return r, func() tea.Msg { return message.DetailMsg{ID: anime.Anime.ID} }
+
+		case key.Matches(msg, r.keys.Bookmark):
+			if len(r.table.SelectedRow()) == 0 {
+				return r, nil
+			}
+
+			anime, err := r.selectedAnime()
+			if err != nil {
+				return r, func() tea.Msg { return message.ErrMsg{Err: err} }
+			}
+
+			return r, toggleBookmark(r.store, anime.Anime.ID, animeTitle(anime.Anime))
+
+		case key.Matches(msg, r.keys.Compare):
+			anime, err := r.selectedAnime()
+			if err != nil {
+				return r, nil
+			}
+			return r, func() tea.Msg { return message.CompareMsg{ID: anime.Anime.ID} }
+
+		case key.Matches(msg, r.keys.Browser, r.keys.CopyLink, r.keys.CopyTitle, r.keys.CopySummary):
+			anime, err := r.selectedAnime()
+			if err != nil {
+				return r, nil
+			}
+			a := anime.Anime
+			switch {
+			case key.Matches(msg, r.keys.Browser):
+				return r, r.openInBrowser(a.ID)
+			case key.Matches(msg, r.keys.CopyLink):
+				return r, r.copyToClipboard(url.AnimePage(a.ID), "link")
+			case key.Matches(msg, r.keys.CopyTitle):
+				return r, r.copyToClipboard(animeTitle(a), "title")
+			}
+			summary := animeSummary(a.ID, animeTitle(a), a.StartSeason, a.MediaType, a.Episodes, a.Mean, anime.Rank.Rank)
+			return r, r.copyToClipboard(summary, "summary")
+
+		case key.Matches(msg, r.keys.Sort):
+			r.setSort((r.sortBy + 1) % sortFieldCount)
+			return r, nil
+
+		case key.Matches(msg, r.keys.Reverse):
+			r.sortDesc = !r.sortDesc
+			r.refresh()
+			return r, nil
+
+		case key.Matches(msg, r.keys.SortColumn):
+			// sort by the nth visible column, 1 to 9 unless remapped
+			n := slices.Index(r.keys.SortColumn.Keys(), msg.String())
+			if n >= 0 && n < len(r.shown) {
+				r.setSort(r.shown[n].sortBy)
+			}
+			return r, nil
+
+		case key.Matches(msg, r.keys.Filter):
+			r.filtering = true
+			return r, r.filter.Focus()
+
+		case key.Matches(msg, r.keys.ClearFilter):
+			if r.filter.Value() != "" {
+				r.filter.Reset()
+				r.refresh()
+				return r, nil
+			}
 		}
 	}
 
@@ -147,10 +337,179 @@ func (r *Rank) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return r, cmd
 }
 
+func (r *Rank) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, r.inputKeys.Cancel):
+		r.filter.Reset()
+		fallthrough
+	case key.Matches(msg, r.inputKeys.Accept):
+		r.filtering = false
+		r.filter.Blur()
+		r.refresh()
+		return r, nil
+	}
+
+	var cmd tea.Cmd
+	r.filter, cmd = r.filter.Update(msg)
+	r.refresh()
+	return r, cmd
+}
+
+// click sorts by the column when the header is clicked, selects the row
+// when a row is clicked and opens its detail on a double click.
+func (r *Rank) click(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	x, y := r.zone.Get(zoneRankTable).Pos(msg)
+	if x < 0 {
+		return r, nil
+	}
+
+	// the header is the first line inside the border
+	if y == r.theme.Frame.GetBorderTopSize() {
+		x -= r.theme.Frame.GetBorderLeftSize() + r.theme.Frame.GetPaddingLeft()
+		for _, c := range r.shown {
+			if x < c.width+cellPadding {
+				r.setSort(c.sortBy)
+				return r, nil
+			}
+			x -= c.width + cellPadding
+		}
+		return r, nil
+	}
+
+	cursor := r.zone.Get(zoneRankCursor)
+	if cursor.IsZero() {
+		return r, nil
+	}
+
+	row := r.table.Cursor() + msg.Y - cursor.StartY
+	if row < 0 || row >= len(r.visible) {
+		return r, nil
+	}
+
+	// move instead of SetCursor so the table keeps its scroll position
+	if row > r.table.Cursor() {
+		r.table.MoveDown(row - r.table.Cursor())
+	} else {
+		r.table.MoveUp(r.table.Cursor() - row)
+	}
+
+	now := time.Now()
+	if r.lastClick.isDouble(row, now) {
+		r.lastClick = click{}
+		id := r.visible[row].Anime.ID
+		return r, func() tea.Msg { return message.DetailMsg{ID: id} }
+	}
+	r.lastClick = click{row: row, at: now}
+	return r, nil
+}
+
+// setSort sorts by field, picking the order that makes sense for it
+// e.g. the highest score first but the lowest rank first.
+func (r *Rank) setSort(field sortField) {
+	if field == r.sortBy {
+		r.sortDesc = !r.sortDesc
+	} else {
+		r.sortBy = field
+		r.sortDesc = field == sortScore || field == sortMembers || field == sortEpisodes || field == sortSeason
+	}
+	r.refresh()
+}
+
+// refresh rebuilds the visible rows from the fetched data, applying the
+// current filter and sort order.
+func (r *Rank) refresh() {
+	pattern := strings.TrimSpace(r.filter.Value())
+
+	r.visible = r.visible[:0]
+	for _, v := range r.anime.AnimeRank {
+		if pattern != "" && !fuzzyMatchAny(pattern, v.Anime.Title, v.Anime.AlternativeTitle.EngTitle, v.Anime.AlternativeTitle.JpnTitle) {
+			continue
+		}
+		r.visible = append(r.visible, v)
+	}
+
+	slices.SortStableFunc(r.visible, func(a, b entity.AnimeRank) int {
+		if r.sortDesc {
+			return r.sortBy.compare(b, a)
+		}
+		return r.sortBy.compare(a, b)
+	})
+
+	rows := make([]table.Row, len(r.visible))
+	for i, anime := range r.visible {
+		row := make(table.Row, len(r.shown))
+		for j, c := range r.shown {
+			row[j] = c.value(anime)
+		}
+		rows[i] = row
+	}
+	r.table.SetRows(rows)
+	if c := r.table.Cursor(); c < 0 {
+		r.table.SetCursor(0)
+	} else if c >= len(rows) {
+		r.table.SetCursor(max(0, len(rows)-1))
+	}
+}
+
+// selectedAnime returns the anime of the selected row.
+func (r *Rank) selectedAnime() (*entity.AnimeRank, error) {
+	i := r.table.Cursor()
+	if i < 0 || i >= len(r.visible) {
+		return nil, errors.New("anime not found")
+	}
+	return &r.visible[i], nil
+}
+
+// prefetch asks for the detail of the selected row and its neighbors once
+// the cursor lands on a new row, so opening it is usually instant.
+func (r *Rank) prefetch() tea.Cmd {
+	i := r.table.Cursor()
+	if i < 0 || i >= len(r.visible) || r.visible[i].Anime.ID == r.prefetched {
+		return nil
+	}
+	r.prefetched = r.visible[i].Anime.ID
+
+	// the selected row first, then outwards
+	ids := []int{r.prefetched}
+	for d := 1; d <= prefetchRadius; d++ {
+		if i+d < len(r.visible) {
+			ids = append(ids, r.visible[i+d].Anime.ID)
+		}
+		if i-d >= 0 {
+			ids = append(ids, r.visible[i-d].Anime.ID)
+		}
+	}
+
+	client := r.client
+	return func() tea.Msg {
+		client.PrefetchDetail(ids...)
+		return nil
+	}
+}
+
+func (r Rank) statusView() string {
+	if r.filtering {
+		return r.filter.View()
+	}
+
+	order := "↑"
+	if r.sortDesc {
+		order = "↓"
+	}
+	status := fmt.Sprintf("sort: %s %s", sortFieldNames[r.sortBy], order)
+	if v := r.filter.Value(); v != "" {
+		status += fmt.Sprintf(" • filter: %q (%d/%d)", v, len(r.visible), len(r.anime.AnimeRank))
+	}
+	return r.theme.Muted.Render(status)
+}
+
 func (r Rank) View() string {
 	if r.isLoading {
 		loadingStyle := lipgloss.NewStyle().Width(r.table.Width()).Height(r.table.Height()).Align(lipgloss.Center, lipgloss.Center)
 		return loadingStyle.Render(lipgloss.JoinHorizontal(lipgloss.Center, r.spinner.View(), " Loading..."))
 	}
-	return baseStyle.Align(lipgloss.Left).Render(r.table.View())
+	return lipgloss.JoinVertical(lipgloss.Left,
+		r.zone.Mark(zoneRankTable, r.theme.Frame.Align(lipgloss.Left).Render(r.table.View())),
+		r.statusView(),
+	)
 }
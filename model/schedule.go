@@ -0,0 +1,246 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+	"github.com/izzanzahrial/tui/url"
+)
+
+// scheduleLimit is how many airing anime are asked for, MAL's maximum,
+// most seasons have fewer than that.
+const scheduleLimit = 500
+
+// jst is Japan Standard Time, which has no daylight saving so a fixed
+// zone is exact and doesn't depend on the system's tzdata.
+var jst = time.FixedZone("JST", 9*60*60)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+type scheduleMsg struct {
+	data *entity.Data
+}
+
+// airing is an anime with its next broadcast in local time.
+type airing struct {
+	anime entity.Anime
+	next  time.Time
+	// known is false when MAL has no broadcast day or time
+	known bool
+}
+
+// Schedule lists the anime airing this season by the local weekday and
+// time of their next episode.
+type Schedule struct {
+	*common
+
+	viewport  viewport.Model
+	anime     []entity.Anime
+	isLoading bool
+	isFocused bool
+	keys      ScheduleKeyMap
+}
+
+func NewSchedule(c *common) *Schedule {
+	vp := viewport.New(0, 0)
+	vp.KeyMap = c.keyMaps.Schedule.KeyMap
+
+	return &Schedule{
+		common:    c,
+		viewport:  vp,
+		isLoading: true,
+		keys:      c.keyMaps.Schedule,
+	}
+}
+
+func (s Schedule) Init() tea.Cmd { return nil }
+
+func (s Schedule) request() tea.Msg {
+	limit := scheduleLimit
+	data, err := s.client.AnimeRank(url.Airing, &limit, nil)
+	if err != nil {
+		return message.ErrMsg{Err: fmt.Errorf("failed to fetch the airing schedule: %w", err), Retry: s.request}
+	}
+	return scheduleMsg{data: data}
+}
+
+func (s *Schedule) Focus() {
+	if !s.isFocused {
+		s.isFocused = true
+		// what counts as today may have changed since the last visit
+		s.render()
+	}
+}
+
+func (s *Schedule) Blur() { s.isFocused = false }
+
+func (s *Schedule) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.viewport.Width = msg.Width
+		s.viewport.Height = msg.Height
+		s.render()
+		return s, nil
+
+	case scheduleMsg:
+		s.anime = s.anime[:0]
+		for _, a := range msg.data.AnimeRank {
+			s.anime = append(s.anime, a.Anime)
+		}
+		s.isLoading = false
+		s.render()
+		return s, nil
+
+	case message.ThemeMsg:
+		s.render()
+		return s, nil
+
+	case tea.MouseMsg:
+		if s.isFocused && leftClick(msg) {
+			for _, a := range s.anime {
+				if s.zone.Get(zoneAnimeLink(a.ID)).InBounds(msg) {
+					id := a.ID
+					return s, func() tea.Msg { return message.DetailMsg{ID: id} }
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	s.viewport, cmd = s.viewport.Update(msg)
+	return s, cmd
+}
+
+// nextBroadcast returns the next time the episode airs after now, in local time.
+func nextBroadcast(b entity.Broadcast, now time.Time) (time.Time, bool) {
+	day, ok := weekdays[b.DayOfWeek]
+	if !ok {
+		return time.Time{}, false
+	}
+	clock, err := time.Parse("15:04", b.StartTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	t := now.In(jst)
+	next := time.Date(t.Year(), t.Month(), t.Day(), clock.Hour(), clock.Minute(), 0, 0, jst)
+	next = next.AddDate(0, 0, (int(day)-int(t.Weekday())+7)%7)
+	if next.Before(t) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next.Local(), true
+}
+
+// render groups the anime by the local weekday they air on, starting today.
+// The broadcasts are placed relative to now every time, so the page stays
+// right when it's left open.
+func (s *Schedule) render() {
+	now := time.Now()
+
+	sorted := make([]airing, len(s.anime))
+	for i, a := range s.anime {
+		next, ok := nextBroadcast(a.Broadcast, now)
+		sorted[i] = airing{anime: a, next: next, known: ok}
+	}
+	slices.SortStableFunc(sorted, func(a, b airing) int {
+		return compareAiring(a, b, now)
+	})
+
+	var (
+		lines []string
+		group = -1
+	)
+	for _, a := range sorted {
+		// days 0 to 6 from today, 7 for the unknown ones
+		day := 7
+		if a.known {
+			day = daysFrom(now, a.next)
+		}
+		if day != group {
+			group = day
+			header := s.theme.SubTab.Render("Unknown broadcast time")
+			switch {
+			case day == 0:
+				header = s.theme.ActiveSub.Render(a.next.Weekday().String() + " • Today")
+			case a.known:
+				header = s.theme.SubTab.Render(a.next.Weekday().String())
+			}
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, header)
+		}
+
+		clock := "--:--"
+		if a.known {
+			clock = a.next.Format("15:04")
+		}
+		title := animeTitle(a.anime)
+		if a.known && daysFrom(now, a.next) == 0 {
+			// the next episode of what already aired today is a week away
+			if a.next.Sub(now) > 24*time.Hour {
+				title = s.theme.Muted.Render(title)
+			} else {
+				title = s.theme.Highlight.Render(title)
+			}
+		}
+		title = s.zone.Mark(zoneAnimeLink(a.anime.ID), title)
+		lines = append(lines, fmt.Sprintf("  %s  %s", s.theme.Muted.Render(clock), title))
+	}
+
+	s.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// compareAiring orders by the local weekday starting today, then by time,
+// the anime without a known broadcast go last.
+func compareAiring(a, b airing, now time.Time) int {
+	switch {
+	case a.known != b.known:
+		if a.known {
+			return -1
+		}
+		return 1
+	case !a.known:
+		return strings.Compare(animeTitle(a.anime), animeTitle(b.anime))
+	}
+	if da, db := daysFrom(now, a.next), daysFrom(now, b.next); da != db {
+		return da - db
+	}
+	return strings.Compare(a.next.Format("15:04"), b.next.Format("15:04"))
+}
+
+// daysFrom is how many local days after now t falls, 0 to 6. An episode
+// that aired earlier today is still listed under today.
+func daysFrom(now, t time.Time) int {
+	return (int(t.Weekday()) - int(now.Weekday()) + 7) % 7
+}
+
+func (s Schedule) View() string {
+	if s.isLoading || len(s.anime) == 0 {
+		text := "Loading the schedule..."
+		if !s.isLoading {
+			text = "Nothing is airing."
+		}
+		return lipgloss.NewStyle().
+			Width(s.viewport.Width).
+			Height(s.viewport.Height).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(text)
+	}
+	return s.viewport.View()
+}
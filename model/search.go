@@ -0,0 +1,277 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+const (
+	// searchLimit is how many results are asked for, more than fit on a
+	// screen without being slow
+	searchLimit = 50
+	// searchMinLength is the shortest query MAL answers
+	searchMinLength = 3
+)
+
+// searchColumns are the Rank columns that make sense without a rank.
+var searchColumns = []string{"title", "media_type", "episodes", "score", "season", "members"}
+
+type searchMsg struct {
+	query string
+	list  *entity.AnimeList
+}
+
+// Search looks anime up on MAL by title, the results can be opened,
+// bookmarked and marked for comparison like the ranking.
+type Search struct {
+	*common
+
+	table   *table.Model
+	columns []rankColumn
+	shown   []rankColumn
+	input   textinput.Model
+	typing  bool
+
+	// query is the last one sent, results are its answer
+	query     string
+	results   []entity.Anime
+	isLoading bool
+
+	keys      SearchKeyMap
+	inputKeys InputKeyMap
+}
+
+func NewSearch(c *common) *Search {
+	columns := newRankColumns(searchColumns)
+	shown := layoutColumns(columns, 100)
+
+	t := table.New(
+		table.WithColumns(tableColumns(shown)),
+		table.WithHeight(10),
+		table.WithKeyMap(c.keyMaps.Search.KeyMap),
+	)
+
+	ti := textinput.New()
+	ti.Prompt = "Search: "
+	ti.Placeholder = fmt.Sprintf("a title, at least %d characters", searchMinLength)
+	ti.CharLimit = 100
+
+	s := &Search{
+		common:    c,
+		table:     &t,
+		columns:   columns,
+		shown:     shown,
+		input:     ti,
+		keys:      c.keyMaps.Search,
+		inputKeys: c.keyMaps.Input,
+	}
+	s.applyTheme()
+	return s
+}
+
+// applyTheme restyles the components that keep a copy of their styles.
+func (s *Search) applyTheme() {
+	s.table.SetStyles(table.Styles{
+		Header:   s.theme.TableHeader,
+		Cell:     s.theme.TableCell,
+		Selected: s.theme.TableSelected,
+	})
+}
+
+func (s Search) Init() tea.Cmd { return nil }
+
+func (s *Search) Focus() { s.table.Focus() }
+func (s *Search) Blur()  { s.table.Blur() }
+
+// Editing reports whether the query input is capturing key presses.
+func (s *Search) Editing() bool { return s.typing }
+
+func (s *Search) KeyMap() help.KeyMap {
+	if s.typing {
+		return s.inputKeys
+	}
+	return s.keys
+}
+
+// request asks MAL for the anime matching query.
+func (s Search) request(query string) tea.Cmd {
+	client := s.client
+	var request func() tea.Msg
+	request = func() tea.Msg {
+		list, err := client.AnimeSearch(query, searchLimit)
+		if err != nil {
+			return message.ErrMsg{Err: fmt.Errorf("failed to search for %q: %w", query, err), Retry: request}
+		}
+		return searchMsg{query: query, list: list}
+	}
+	return request
+}
+
+func (s *Search) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// the table panics if a row has more cells than columns
+		s.table.SetRows(nil)
+		width := msg.Width - s.theme.Frame.GetHorizontalFrameSize()
+		s.shown = layoutColumns(s.columns, width)
+		s.table.SetColumns(tableColumns(s.shown))
+		s.refresh()
+
+		s.table.SetWidth(width)
+		// leave one line for the query input or the status
+		s.table.SetHeight(msg.Height - 1)
+		s.input.Width = msg.Width - len(s.input.Prompt) - 2
+		return s, nil
+
+	case message.ThemeMsg:
+		s.applyTheme()
+		return s, nil
+
+	case searchMsg:
+		// an answer to a query typed over since is dropped
+		if msg.query != s.query {
+			return s, nil
+		}
+		s.isLoading = false
+		s.results = s.results[:0]
+		for _, d := range msg.list.Data {
+			s.results = append(s.results, d.Anime)
+		}
+		s.refresh()
+		s.table.SetCursor(0)
+		return s, nil
+
+	case tea.KeyMsg:
+		if s.typing {
+			return s.updateInput(msg)
+		}
+
+		if !s.table.Focused() {
+			return s, nil
+		}
+
+		if key.Matches(msg, s.keys.Search) {
+			s.typing = true
+			s.input.SetValue(s.query)
+			s.input.CursorEnd()
+			return s, s.input.Focus()
+		}
+
+		anime, ok := s.selected()
+		switch {
+		case key.Matches(msg, s.keys.Open):
+			if !ok {
+				return s, nil
+			}
+			return s, func() tea.Msg { return message.DetailMsg{ID: anime.ID} }
+
+		case key.Matches(msg, s.keys.Bookmark):
+			if !ok {
+				return s, nil
+			}
+			return s, toggleBookmark(s.store, anime.ID, animeTitle(anime))
+
+		case key.Matches(msg, s.keys.Compare):
+			if !ok {
+				return s, nil
+			}
+			return s, func() tea.Msg { return message.CompareMsg{ID: anime.ID} }
+		}
+	}
+
+	*s.table, cmd = s.table.Update(msg)
+	return s, cmd
+}
+
+func (s *Search) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, s.inputKeys.Cancel):
+		s.typing = false
+		s.input.Blur()
+		return s, nil
+
+	case key.Matches(msg, s.inputKeys.Accept):
+		query := strings.TrimSpace(s.input.Value())
+		if len([]rune(query)) < searchMinLength {
+			text := fmt.Sprintf("Type at least %d characters to search", searchMinLength)
+			return s, func() tea.Msg { return message.NotifyMsg{Severity: message.SeverityWarning, Text: text} }
+		}
+
+		s.typing = false
+		s.input.Blur()
+		s.query = query
+		s.isLoading = true
+		return s, s.request(query)
+	}
+
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	return s, cmd
+}
+
+// refresh rebuilds the rows from the results.
+func (s *Search) refresh() {
+	rows := make([]table.Row, len(s.results))
+	for i, a := range s.results {
+		row := make(table.Row, len(s.shown))
+		for j, c := range s.shown {
+			row[j] = c.value(entity.AnimeRank{Anime: a})
+		}
+		rows[i] = row
+	}
+	s.table.SetRows(rows)
+}
+
+func (s *Search) selected() (entity.Anime, bool) {
+	i := s.table.Cursor()
+	if i < 0 || i >= len(s.results) {
+		return entity.Anime{}, false
+	}
+	return s.results[i], true
+}
+
+func (s Search) statusView() string {
+	if s.typing {
+		return s.input.View()
+	}
+	if s.query == "" || s.isLoading {
+		return ""
+	}
+	return s.theme.Muted.Render(fmt.Sprintf("%d results for %q • %s to search again", len(s.results), s.query, s.keys.Search.Help().Key))
+}
+
+func (s Search) View() string {
+	var text string
+	switch {
+	case s.isLoading:
+		text = fmt.Sprintf("Searching MAL for %q...", s.query)
+	case s.query == "":
+		text = fmt.Sprintf("Press %s to search MAL by title.", s.keys.Search.Help().Key)
+	case len(s.results) == 0:
+		text = fmt.Sprintf("Nothing on MAL matches %q.", s.query)
+	}
+	if text != "" {
+		placeholder := lipgloss.NewStyle().
+			Width(s.table.Width()).
+			Height(s.table.Height()).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(text)
+		return lipgloss.JoinVertical(lipgloss.Left, placeholder, s.statusView())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		s.theme.Frame.Align(lipgloss.Left).Render(s.table.View()),
+		s.statusView(),
+	)
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+	"github.com/izzanzahrial/tui/url"
+	"github.com/izzanzahrial/tui/xdg"
+)
+
+// openInBrowser opens the MAL page of the anime with the desktop's browser.
+// Over SSH that desktop is the server's, so the link is copied instead.
+func (c *common) openInBrowser(id int) tea.Cmd {
+	if c.remote {
+		return c.copyToClipboard(url.AnimePage(id), "link, there's no browser over SSH")
+	}
+	return func() tea.Msg {
+		if err := xdg.Open(url.AnimePage(id)); err != nil {
+			return message.ErrMsg{Err: fmt.Errorf("failed to open the browser: %w", err)}
+		}
+		return nil
+	}
+}
+
+// copyToClipboard puts text in the clipboard of the terminal the app is
+// shown in through OSC 52, which works over SSH as long as the terminal
+// supports it. what names the text in the notification.
+func (c *common) copyToClipboard(text, what string) tea.Cmd {
+	out, getenv := c.clipboard, c.getenv
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		// multiplexers swallow the sequence unless it's wrapped for them
+		switch {
+		case getenv("TMUX") != "":
+			seq = seq.Tmux()
+		case strings.HasPrefix(getenv("TERM"), "screen"):
+			seq = seq.Screen()
+		}
+		if _, err := seq.WriteTo(out); err != nil {
+			return message.ErrMsg{Err: fmt.Errorf("failed to copy the %s: %w", what, err)}
+		}
+		return message.NotifyMsg{Severity: message.SeverityInfo, Text: "Copied the " + what}
+	}
+}
+
+// animeSummary is a short description to paste in a chat, e.g.
+//
+//	Frieren: Beyond Journey's End (fall 2023, TV, 28 eps)
+//	Score 9.30 • Rank #1
+//	https://myanimelist.net/anime/52991
+func animeSummary(id int, title string, season entity.Season, mediaType entity.MediaType, episodes int, mean float64, rank int) string {
+	var about []string
+	if season.Year != 0 {
+		about = append(about, fmt.Sprintf("%s %d", season.Season, season.Year))
+	}
+	if mediaType != "" {
+		about = append(about, mediaType.String())
+	}
+	if episodes > 0 {
+		about = append(about, strconv.Itoa(episodes)+" eps")
+	}
+
+	lines := []string{title}
+	if len(about) > 0 {
+		lines[0] += " (" + strings.Join(about, ", ") + ")"
+	}
+
+	var stats []string
+	if mean != 0 {
+		stats = append(stats, fmt.Sprintf("Score %.2f", mean))
+	}
+	if rank != 0 {
+		stats = append(stats, "Rank #"+strconv.Itoa(rank))
+	}
+	if len(stats) > 0 {
+		lines = append(lines, strings.Join(stats, " • "))
+	}
+
+	return strings.Join(append(lines, url.AnimePage(id)), "\n")
+}
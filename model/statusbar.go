@@ -0,0 +1,113 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izzanzahrial/tui/message"
+)
+
+// How long a toast stays before it's dismissed on its own.
+var toastDurations = map[message.Severity]time.Duration{
+	message.SeverityInfo:    3 * time.Second,
+	message.SeverityWarning: 5 * time.Second,
+	message.SeverityError:   8 * time.Second,
+}
+
+// toastWithRetryDuration gives the user more time to press retry.
+const toastWithRetryDuration = 15 * time.Second
+
+type toast struct {
+	id       int
+	severity message.Severity
+	text     string
+	retry    tea.Cmd
+}
+
+// toastExpiredMsg dismisses the toast with the id, unless it was already replaced.
+type toastExpiredMsg struct{ id int }
+
+// StatusBar shows one toast at a time, a new one replaces the current one.
+type StatusBar struct {
+	*common
+
+	toast  *toast
+	nextID int
+	width  int
+}
+
+func NewStatusBar(c *common) *StatusBar {
+	return &StatusBar{common: c}
+}
+
+func (s StatusBar) Init() tea.Cmd { return nil }
+
+// push shows a new toast and returns the command that dismisses it later.
+func (s *StatusBar) push(severity message.Severity, text string, retry tea.Cmd) tea.Cmd {
+	s.nextID++
+	s.toast = &toast{id: s.nextID, severity: severity, text: text, retry: retry}
+
+	d := toastDurations[severity]
+	if retry != nil {
+		d = toastWithRetryDuration
+	}
+
+	id := s.nextID
+	return tea.Tick(d, func(time.Time) tea.Msg { return toastExpiredMsg{id: id} })
+}
+
+// CanRetry reports whether the current toast comes with a retry action.
+func (s *StatusBar) CanRetry() bool {
+	return s.toast != nil && s.toast.retry != nil
+}
+
+// Retry dismisses the toast and returns its retry action.
+func (s *StatusBar) Retry() tea.Cmd {
+	if !s.CanRetry() {
+		return nil
+	}
+	cmd := s.toast.retry
+	s.toast = nil
+	return cmd
+}
+
+func (s *StatusBar) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+
+	case message.NotifyMsg:
+		return s, s.push(msg.Severity, msg.Text, nil)
+
+	case message.ErrMsg:
+		return s, s.push(message.SeverityError, msg.Error(), msg.Retry)
+
+	case toastExpiredMsg:
+		if s.toast != nil && s.toast.id == msg.id {
+			s.toast = nil
+		}
+	}
+	return s, nil
+}
+
+func (s StatusBar) View() string {
+	if s.toast == nil {
+		return ""
+	}
+
+	text := s.toast.text
+	if s.toast.retry != nil {
+		text += " (" + s.keyMaps.Global.Retry.Help().Key + " to retry)"
+	}
+
+	st := s.theme.StatusInfo
+	switch s.toast.severity {
+	case message.SeverityWarning:
+		st = s.theme.StatusWarning
+		text = "! " + text
+	case message.SeverityError:
+		st = s.theme.StatusError
+		text = "✗ " + text
+	}
+	return st.MaxWidth(s.width).Render(text)
+}
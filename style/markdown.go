@@ -0,0 +1,72 @@
+package style
+
+import "github.com/charmbracelet/glamour/ansi"
+
+// markdownStyle renders Markdown, e.g. the synopsis, in the palette's
+// colors. Headings and emphasis stand out by weight and slant too, so they
+// still do without colors.
+func markdownStyle(p Palette) ansi.StyleConfig {
+	color := func(c string) *string {
+		if c == "" {
+			return nil
+		}
+		return &c
+	}
+	on := func() *bool { b := true; return &b }
+	var noMargin uint
+	indent, quoteToken := uint(1), "│ "
+
+	return ansi.StyleConfig{
+		Document: ansi.StyleBlock{
+			StylePrimitive: ansi.StylePrimitive{Color: color(p.Text)},
+			Margin:         &noMargin,
+		},
+		Heading: ansi.StyleBlock{
+			StylePrimitive: ansi.StylePrimitive{
+				BlockSuffix: "\n",
+				Color:       color(p.Primary),
+				Bold:        on(),
+			},
+		},
+		H1: ansi.StyleBlock{
+			StylePrimitive: ansi.StylePrimitive{
+				Prefix:          " ",
+				Suffix:          " ",
+				Color:           color(p.OnPrimary),
+				BackgroundColor: color(p.Primary),
+			},
+		},
+		H3: ansi.StyleBlock{
+			StylePrimitive: ansi.StylePrimitive{Color: color(p.Accent)},
+		},
+		BlockQuote: ansi.StyleBlock{
+			StylePrimitive: ansi.StylePrimitive{Color: color(p.Muted)},
+			Indent:         &indent,
+			IndentToken:    &quoteToken,
+		},
+		List:        ansi.StyleList{LevelIndent: 2},
+		Item:        ansi.StylePrimitive{BlockPrefix: "• "},
+		Enumeration: ansi.StylePrimitive{BlockPrefix: ". "},
+		Emph: ansi.StylePrimitive{
+			Color:  color(p.Muted),
+			Italic: on(),
+		},
+		Strong:        ansi.StylePrimitive{Bold: on()},
+		Strikethrough: ansi.StylePrimitive{CrossedOut: on()},
+		HorizontalRule: ansi.StylePrimitive{
+			Color:  color(p.Border),
+			Format: "\n────────\n",
+		},
+		Link: ansi.StylePrimitive{
+			Color:     color(p.Accent),
+			Underline: on(),
+		},
+		LinkText: ansi.StylePrimitive{
+			Color: color(p.Accent),
+			Bold:  on(),
+		},
+		Code: ansi.StyleBlock{
+			StylePrimitive: ansi.StylePrimitive{Color: color(p.Accent)},
+		},
+	}
+}
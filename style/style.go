@@ -1,15 +1,12 @@
 package style
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
 
 var (
-	// general
-	Normal    = lipgloss.Color("#EEEEEE")
-	Subtle    = lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#383838"}
-	Highlight = lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"}
-
-	Base = lipgloss.NewStyle().Foreground(Normal)
-
 	// tabs
 	ActiveTabBorder = lipgloss.Border{
 		Top:         "─",
@@ -32,38 +29,201 @@ var (
 		BottomLeft:  "┴",
 		BottomRight: "┴",
 	}
+)
 
-	Tab = lipgloss.NewStyle().
-		Border(TabBorder, true).
-		BorderForeground(Highlight).
-		Padding(0, 1)
+// Palette is the handful of colors a theme is made of, every style in the
+// app is derived from it. Colors are anything lipgloss.Color accepts,
+// e.g. "#7D56F4" or "57".
+type Palette struct {
+	Name string `json:"name"`
+
+	Text   string `json:"text"`
+	Muted  string `json:"muted"`
+	Border string `json:"border"`
+
+	// Primary is used for tabs, titles and other highlighted elements,
+	// OnPrimary for text drawn on top of it.
+	Primary   string `json:"primary"`
+	OnPrimary string `json:"on_primary"`
+	Accent    string `json:"accent"`
+
+	SelectedFg string `json:"selected_fg"`
+	SelectedBg string `json:"selected_bg"`
+
+	Warning string `json:"warning"`
+	Error   string `json:"error"`
+	OnError string `json:"on_error"`
+}
+
+// Theme holds every style used in the app.
+type Theme struct {
+	Name    string
+	Palette Palette
+	// NoColor is set when the theme was built without colors,
+	// pictures can't be drawn then
+	NoColor bool
+
+	// general
+	Frame     lipgloss.Style
+	Base      lipgloss.Style
+	Muted     lipgloss.Style
+	Separator lipgloss.Style
+	Info      lipgloss.Style
+	Spinner   lipgloss.Style
+
+	// menubar
+	Title     lipgloss.Style
+	Tab       lipgloss.Style
+	ActiveTab lipgloss.Style
+	TabGap    lipgloss.Style
+
+	// tables
+	TableHeader   lipgloss.Style
+	TableCell     lipgloss.Style
+	TableSelected lipgloss.Style
+
+	// detail
+	DetailTitle lipgloss.Style
+	SubTab      lipgloss.Style
+	ActiveSub   lipgloss.Style
+	Bar         lipgloss.Style
+	Highlight   lipgloss.Style
+	// Picture carries the renderer's color profile, the colors
+	// come from the picture itself
+	Picture lipgloss.Style
+
+	// Markdown renders the synopsis and background, in ColorProfile,
+	// which is the renderer's
+	Markdown     ansi.StyleConfig
+	ColorProfile termenv.Profile
+
+	// help
+	HelpKey  lipgloss.Style
+	HelpDesc lipgloss.Style
+	HelpBox  lipgloss.Style
+
+	// status bar
+	StatusInfo    lipgloss.Style
+	StatusWarning lipgloss.Style
+	StatusError   lipgloss.Style
+
+	// error
+	ErrorTitle lipgloss.Style
+	ErrorBody  lipgloss.Style
+	ErrorBox   lipgloss.Style
+}
+
+// NewTheme derives every style from the palette using the renderer, so the
+// colors are degraded to whatever the renderer's color profile supports.
+// With noColor every color is dropped and emphasis is done with bold,
+// underline and reverse instead, following https://no-color.org.
+func NewTheme(r *lipgloss.Renderer, p Palette, noColor bool) Theme {
+	if noColor {
+		p = Palette{Name: p.Name}
+	}
+
+	text := lipgloss.Color(p.Text)
+	muted := lipgloss.Color(p.Muted)
+	border := lipgloss.Color(p.Border)
+	primary := lipgloss.Color(p.Primary)
+	errColor := lipgloss.Color(p.Error)
+
+	t := Theme{Name: p.Name, Palette: p, NoColor: noColor}
 
-	ActiveTab = Tab.Border(ActiveTabBorder, true)
+	t.Frame = r.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(0, 1)
+	t.Base = r.NewStyle().Foreground(text)
+	t.Muted = r.NewStyle().Foreground(muted)
+	t.Separator = r.NewStyle().Foreground(border)
+	t.Info = t.Base.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderTop(true).
+		BorderForeground(border)
+	t.Spinner = r.NewStyle().Foreground(lipgloss.Color(p.Accent))
 
-	TabGap = Tab.
+	t.Title = r.NewStyle().
+		MarginLeft(1).
+		MarginRight(5).
+		Padding(0, 1).
+		Foreground(primary).SetString("ANIME TUI")
+	t.Tab = r.NewStyle().
+		Border(TabBorder, true).
+		BorderForeground(primary).
+		Padding(0, 1)
+	t.ActiveTab = t.Tab.Border(ActiveTabBorder, true)
+	t.TabGap = t.Tab.
 		BorderTop(false).
 		BorderLeft(false).
 		BorderRight(false)
 
-	Title = lipgloss.NewStyle().
-		MarginLeft(1).
-		MarginRight(5).
+	t.TableHeader = r.NewStyle().
 		Padding(0, 1).
-		Foreground(lipgloss.Color("#874BFD")).SetString("ANIME TUI")
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(border).
+		BorderBottom(true).
+		Bold(false)
+	t.TableCell = r.NewStyle().Padding(0, 1)
+	t.TableSelected = r.NewStyle().
+		Foreground(lipgloss.Color(p.SelectedFg)).
+		Background(lipgloss.Color(p.SelectedBg)).
+		Bold(false)
 
-	Desc = Base.MarginTop(1)
+	t.DetailTitle = r.NewStyle().
+		Foreground(lipgloss.Color(p.OnPrimary)).
+		Background(primary).
+		Bold(true).
+		Padding(0, 1)
+	t.SubTab = t.Muted.Padding(0, 1)
+	t.ActiveSub = r.NewStyle().Foreground(primary).Bold(true).Padding(0, 1)
+	t.Bar = r.NewStyle().Foreground(lipgloss.Color(p.Accent))
+	t.Highlight = r.NewStyle().Foreground(lipgloss.Color(p.Accent)).Bold(true)
+	t.Picture = r.NewStyle()
+	t.Markdown = markdownStyle(p)
+	t.ColorProfile = r.ColorProfile()
 
-	Info = Base.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderTop(true).
-		BorderForeground(Subtle)
+	t.HelpKey = r.NewStyle().Foreground(text)
+	t.HelpDesc = t.Muted
+	t.HelpBox = r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primary).
+		Padding(1, 2)
 
-	DetailTitle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
-			Bold(true).
-			Padding(0, 1)
+	t.StatusInfo = r.NewStyle().Foreground(primary)
+	t.StatusWarning = r.NewStyle().Foreground(lipgloss.Color(p.Warning)).Bold(true)
+	t.StatusError = r.NewStyle().Foreground(errColor).Bold(true)
 
-	Separator = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240"))
-)
+	t.ErrorTitle = r.NewStyle().
+		Foreground(lipgloss.Color(p.OnError)).
+		Background(errColor).
+		Bold(true).
+		Padding(0, 1)
+	t.ErrorBody = r.NewStyle().
+		Foreground(text).
+		Padding(1)
+	t.ErrorBox = r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(errColor)
+
+	switch {
+	case noColor:
+		t.Title = t.Title.Bold(true)
+		t.ActiveTab = t.ActiveTab.Bold(true).Underline(true)
+		t.TableHeader = t.TableHeader.Bold(true)
+		t.TableSelected = t.TableSelected.Reverse(true)
+		t.DetailTitle = t.DetailTitle.Reverse(true)
+		t.ActiveSub = t.ActiveSub.Underline(true)
+		t.Highlight = t.Highlight.Underline(true)
+		t.HelpKey = t.HelpKey.Bold(true)
+		t.ErrorTitle = t.ErrorTitle.Reverse(true)
+		t.StatusError = t.StatusError.Reverse(true)
+	case r.ColorProfile() == termenv.ANSI:
+		// 16 colors can turn the palette into something close to the
+		// text color, so make sure the important parts still stand out
+		t.ActiveTab = t.ActiveTab.Bold(true)
+		t.TableSelected = t.TableSelected.Bold(true)
+	}
+
+	return t
+}
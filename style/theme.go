@@ -0,0 +1,136 @@
+package style
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/izzanzahrial/tui/xdg"
+)
+
+var (
+	Dark = Palette{
+		Name:       "dark",
+		Text:       "#EEEEEE",
+		Muted:      "244",
+		Border:     "240",
+		Primary:    "#7D56F4",
+		OnPrimary:  "#FAFAFA",
+		Accent:     "205",
+		SelectedFg: "229",
+		SelectedBg: "57",
+		Warning:    "#FFAF00",
+		Error:      "#FF5F87",
+		OnError:    "#F1F1F1",
+	}
+
+	Light = Palette{
+		Name:       "light",
+		Text:       "#1A1A1A",
+		Muted:      "#6C6C6C",
+		Border:     "#BDBDBD",
+		Primary:    "#874BFD",
+		OnPrimary:  "#FFFFFF",
+		Accent:     "#D7005F",
+		SelectedFg: "#FFFFFF",
+		SelectedBg: "#874BFD",
+		Warning:    "#AF5F00",
+		Error:      "#D70000",
+		OnError:    "#FFFFFF",
+	}
+
+	HighContrast = Palette{
+		Name:       "high-contrast",
+		Text:       "#FFFFFF",
+		Muted:      "#FFFFFF",
+		Border:     "#FFFFFF",
+		Primary:    "#FFFF00",
+		OnPrimary:  "#000000",
+		Accent:     "#00FFFF",
+		SelectedFg: "#000000",
+		SelectedBg: "#FFFF00",
+		Warning:    "#FF8700",
+		Error:      "#FF0000",
+		OnError:    "#FFFFFF",
+	}
+
+	// Catppuccin is based on the Catppuccin Mocha colors.
+	Catppuccin = Palette{
+		Name:       "catppuccin",
+		Text:       "#CDD6F4",
+		Muted:      "#7F849C",
+		Border:     "#45475A",
+		Primary:    "#CBA6F7",
+		OnPrimary:  "#1E1E2E",
+		Accent:     "#F5C2E7",
+		SelectedFg: "#1E1E2E",
+		SelectedBg: "#89B4FA",
+		Warning:    "#F9E2AF",
+		Error:      "#F38BA8",
+		OnError:    "#1E1E2E",
+	}
+)
+
+// DefaultThemeDir returns the directory user themes are loaded from.
+func DefaultThemeDir() string {
+	return filepath.Join(xdg.ConfigDir(), "themes")
+}
+
+// Palettes returns the built-in palettes followed by the user themes in dir.
+// Every *.json file in dir is a Palette, colors it leaves out are taken from
+// the dark theme and a user theme replaces a built-in one with the same name.
+func Palettes(dir string) ([]Palette, error) {
+	palettes := []Palette{Dark, Light, HighContrast, Catppuccin}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		p, err := loadPalette(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if i := indexPalette(palettes, p.Name); i >= 0 {
+			palettes[i] = p
+		} else {
+			palettes = append(palettes, p)
+		}
+	}
+
+	return palettes, nil
+}
+
+// FindPalette returns the index of the named palette, or 0 (the first one) if there's none.
+func FindPalette(palettes []Palette, name string) int {
+	return max(0, indexPalette(palettes, name))
+}
+
+func indexPalette(palettes []Palette, name string) int {
+	for i, p := range palettes {
+		if strings.EqualFold(p.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func loadPalette(path string) (Palette, error) {
+	p := Dark
+	p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return p, fmt.Errorf("failed to read theme %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(b, &p); err != nil {
+		return p, fmt.Errorf("failed to decode theme %s: %w", path, err)
+	}
+
+	return p, nil
+}
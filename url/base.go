@@ -1,16 +1,84 @@
 package url
 
-import "resty.dev/v3"
+import (
+	"fmt"
 
-var baseURL = "https://api.myanimelist.net/v2/anime"
+	"github.com/izzanzahrial/tui/entity"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+	"resty.dev/v3"
+)
+
+var (
+	apiURL   = "https://api.myanimelist.net/v2"
+	baseURL  = apiURL + "/anime"
+	mangaURL = apiURL + "/manga"
+)
+
+// webURL is the site the API belongs to, for links meant for people.
+const webURL = "https://myanimelist.net"
+
+// AnimePage is the MAL web page of the anime.
+func AnimePage(id int) string {
+	return fmt.Sprintf("%s/anime/%d", webURL, id)
+}
 
 const ClientIDHeader = "X-MAL-CLIENT-ID"
 
+// MAL doesn't document its limits, this keeps well below where it starts
+// answering 403, prefetching included.
+const (
+	requestsPerSecond = 3
+	requestBurst      = 3
+)
+
+// Logger receives a trace of every request the client makes.
+type Logger interface {
+	Infof(format string, v ...any)
+	Errorf(format string, v ...any)
+}
+
 type Client struct {
-	client *resty.Client
+	client    *resty.Client
+	limiter   *rate.Limiter
+	cache     *detailCache[entity.Detail]
+	manga     *detailCache[entity.MangaDetail]
+	franchise *detailCache[entity.Franchise]
+	flight    singleflight.Group
+	prefetch  *prefetcher
 }
 
 func NewClient() *Client {
-	c := resty.New()
-	return &Client{client: c}
+	c := &Client{
+		client:    resty.New(),
+		limiter:   rate.NewLimiter(requestsPerSecond, requestBurst),
+		cache:     newDetailCache[entity.Detail](),
+		manga:     newDetailCache[entity.MangaDetail](),
+		franchise: newDetailCache[entity.Franchise](),
+		prefetch:  &prefetcher{},
+	}
+	c.client.AddRequestMiddleware(func(_ *resty.Client, req *resty.Request) error {
+		return c.limiter.Wait(req.Context())
+	})
+	return c
+}
+
+// SetLogger traces every request, with its status and duration, to l.
+func (c *Client) SetLogger(l Logger) *Client {
+	c.client.AddResponseMiddleware(func(_ *resty.Client, res *resty.Response) error {
+		path := res.Request.URL
+		if res.Request.RawRequest != nil {
+			path = res.Request.RawRequest.URL.Path
+		}
+		if res.IsError() {
+			l.Errorf("%s %s: %s (%s)", res.Request.Method, path, res.Status(), res.Duration())
+		} else {
+			l.Infof("%s %s: %s (%s)", res.Request.Method, path, res.Status(), res.Duration())
+		}
+		return nil
+	})
+	c.client.OnError(func(req *resty.Request, err error) {
+		l.Errorf("%s %s: %v", req.Method, req.URL, err)
+	})
+	return c
 }
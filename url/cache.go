@@ -0,0 +1,72 @@
+package url
+
+import (
+	"sync"
+	"time"
+)
+
+// detailTTL is how long a fetched detail is served from memory,
+// scores and rankings don't move much within a session.
+const detailTTL = 10 * time.Minute
+
+// detailCacheSize caps the entries kept, the client is shared by every
+// SSH session so the cache would otherwise grow with all they open.
+const detailCacheSize = 500
+
+type cachedDetail[T any] struct {
+	detail    *T
+	fetchedAt time.Time
+}
+
+// detailCache keeps recently fetched details so reopening an anime, or
+// opening one that was prefetched, doesn't wait for the network.
+type detailCache[T any] struct {
+	mu      sync.Mutex
+	entries map[int]cachedDetail[T]
+}
+
+func newDetailCache[T any]() *detailCache[T] {
+	return &detailCache[T]{entries: make(map[int]cachedDetail[T])}
+}
+
+func (c *detailCache[T]) get(id int) (*T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(e.fetchedAt) > detailTTL {
+		delete(c.entries, id)
+		return nil, false
+	}
+	return e.detail, true
+}
+
+// put drops the expired entries, and the oldest one when the cache is still
+// full, before adding d.
+func (c *detailCache[T]) put(id int, d *T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range c.entries {
+		if now.Sub(e.fetchedAt) > detailTTL {
+			delete(c.entries, k)
+		}
+	}
+
+	if _, ok := c.entries[id]; !ok && len(c.entries) >= detailCacheSize {
+		var oldest int
+		var oldestAt time.Time
+		for k, e := range c.entries {
+			if oldestAt.IsZero() || e.fetchedAt.Before(oldestAt) {
+				oldest, oldestAt = k, e.fetchedAt
+			}
+		}
+		delete(c.entries, oldest)
+	}
+
+	c.entries[id] = cachedDetail[T]{detail: d, fetchedAt: now}
+}
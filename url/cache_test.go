@@ -0,0 +1,50 @@
+package url
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetailCacheSweep(t *testing.T) {
+	c := newDetailCache[int]()
+	v := 1
+	c.put(1, &v)
+	c.put(2, &v)
+	// 1 expired a while ago
+	c.entries[1] = cachedDetail[int]{detail: &v, fetchedAt: time.Now().Add(-2 * detailTTL)}
+
+	c.put(3, &v)
+	if _, ok := c.entries[1]; ok {
+		t.Error("expired entry is still kept after a put")
+	}
+	if len(c.entries) != 2 {
+		t.Errorf("cache has %d entries, want 2", len(c.entries))
+	}
+}
+
+func TestDetailCacheSize(t *testing.T) {
+	c := newDetailCache[int]()
+	v := 1
+	for id := range detailCacheSize {
+		c.put(id, &v)
+	}
+	// 0 is the oldest, make sure of it even if the clock didn't move
+	c.entries[0] = cachedDetail[int]{detail: &v, fetchedAt: time.Now().Add(-time.Minute)}
+
+	// putting one that's already kept doesn't evict anything
+	c.put(1, &v)
+	if len(c.entries) != detailCacheSize {
+		t.Fatalf("cache has %d entries, want %d", len(c.entries), detailCacheSize)
+	}
+
+	c.put(detailCacheSize, &v)
+	if len(c.entries) != detailCacheSize {
+		t.Errorf("cache has %d entries, want %d", len(c.entries), detailCacheSize)
+	}
+	if _, ok := c.get(0); ok {
+		t.Error("the oldest entry is still kept once the cache is full")
+	}
+	if _, ok := c.get(detailCacheSize); !ok {
+		t.Error("the new entry isn't kept")
+	}
+}
@@ -0,0 +1,271 @@
+package url
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/izzanzahrial/tui/entity"
+)
+
+// conformanceCase is one request of the client and the entity each anime
+// of its answer decodes into.
+type conformanceCase struct {
+	name   string
+	fields []string
+	// unkept are the fields asked for that the entity leaves out on purpose,
+	// any other has to decode into it
+	unkept []string
+	// list answers have the anime under data[].node
+	list   bool
+	entity reflect.Type
+	// path and query ask MAL for a fresh answer, relative to baseURL, the
+	// recorded one is testdata/<name>.json
+	path  string
+	query map[string]string
+}
+
+var conformanceCases = []conformanceCase{
+	{
+		name: "rank", fields: rankFields, list: true, entity: reflect.TypeFor[entity.Anime](),
+		path: "/ranking", query: map[string]string{"ranking_type": "all", "limit": "3"},
+	},
+	{
+		name: "detail", fields: detailFields, entity: reflect.TypeFor[entity.Detail](),
+		unkept: []string{
+			"end_date", "num_scoring_users", "nsfw", "created_at", "updated_at",
+			"broadcast", "source", "average_episode_duration",
+		},
+		path: "/52991",
+	},
+	{
+		name: "search", fields: rankFields, list: true, entity: reflect.TypeFor[entity.Anime](),
+		query: map[string]string{"q": "frieren", "limit": "2"},
+	},
+	{
+		name: "season", fields: rankFields, list: true, entity: reflect.TypeFor[entity.Anime](),
+		path: "/season/2023/fall", query: map[string]string{"sort": "anime_score", "limit": "2"},
+	},
+}
+
+// TestConformance checks that the entities decode what the client asks MAL
+// for: every struct tag is well formed and named like MAL names the field,
+// every requested field is in the answer, and every one the entities keep
+// ends up decoded. The answers are the recorded ones in testdata.
+func TestConformance(t *testing.T) {
+	for _, cc := range conformanceCases {
+		t.Run(cc.name, func(t *testing.T) {
+			body, err := os.ReadFile("testdata/" + cc.name + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range cc.check(body) {
+				t.Error(p)
+			}
+		})
+	}
+}
+
+// TestConformanceLive is TestConformance against fresh answers, which
+// catches MAL changing its schema. It needs the network and a client ID,
+// so it only runs with MAL_LIVE=1, e.g.
+//
+//	MAL_LIVE=1 CLIENT_ID=... go test ./url -run Live
+func TestConformanceLive(t *testing.T) {
+	if os.Getenv("MAL_LIVE") == "" {
+		t.Skip("set MAL_LIVE=1 and CLIENT_ID to check MAL's live answers")
+	}
+	if os.Getenv("CLIENT_ID") == "" {
+		t.Fatal("CLIENT_ID is needed to ask MAL")
+	}
+
+	c := NewClient()
+	for _, cc := range conformanceCases {
+		t.Run(cc.name, func(t *testing.T) {
+			body, err := c.fetchRaw(cc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range cc.check(body) {
+				t.Error(p)
+			}
+		})
+	}
+}
+
+func (c *Client) fetchRaw(cc conformanceCase) ([]byte, error) {
+	res, err := c.client.R().
+		SetHeader(ClientIDHeader, os.Getenv("CLIENT_ID")).
+		SetQueryParams(cc.query).
+		SetQueryParam("fields", strings.Join(cc.fields, ",")).
+		Get(baseURL + cc.path)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("unexpected response: %s", res.Status())
+	}
+	return res.Bytes(), nil
+}
+
+func (cc conformanceCase) check(body []byte) []string {
+	items, err := splitItems(body, cc.list)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if len(items) == 0 {
+		return []string{"the answer has no anime to check"}
+	}
+
+	problems := checkTags(cc.entity, cc.entity.Name(), map[reflect.Type]bool{})
+
+	decoded := make([]reflect.Value, len(items))
+	for i, item := range items {
+		v := reflect.New(cc.entity)
+		if err := json.Unmarshal(item.body, v.Interface()); err != nil {
+			return append(problems, fmt.Sprintf("can't decode anime %d: %v", item.id, err))
+		}
+		decoded[i] = v.Elem()
+	}
+
+	byTag := make(map[string]reflect.StructField)
+	for _, f := range reflect.VisibleFields(cc.entity) {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || !f.IsExported() {
+			continue
+		}
+		byTag[name] = f
+
+		// MAL matches the case of its fields, decoding doesn't need to, so
+		// a tag that only matches ignoring case decodes but is still wrong
+		if key, ok := items.keyFold(name); ok && key != name {
+			problems = append(problems, fmt.Sprintf("%s.%s is tagged %q, MAL calls it %q", cc.entity.Name(), f.Name, name, key))
+		}
+	}
+
+	for _, field := range cc.fields {
+		if !items.has(field) {
+			problems = append(problems, fmt.Sprintf("MAL left out %q, which the client asks for", field))
+			continue
+		}
+		f, ok := byTag[field]
+		if !ok {
+			if !slices.Contains(cc.unkept, field) {
+				problems = append(problems, fmt.Sprintf("no field of %s decodes %q", cc.entity.Name(), field))
+			}
+			continue
+		}
+		populated := false
+		for _, v := range decoded {
+			if !v.FieldByIndex(f.Index).IsZero() {
+				populated = true
+				break
+			}
+		}
+		if !populated {
+			problems = append(problems, fmt.Sprintf("%s.%s stays empty although MAL sends %q", cc.entity.Name(), f.Name, field))
+		}
+	}
+	return problems
+}
+
+type rawItem struct {
+	id   int
+	body json.RawMessage
+	keys map[string]json.RawMessage
+}
+
+type rawItems []rawItem
+
+func splitItems(body []byte, list bool) (rawItems, error) {
+	var bodies []json.RawMessage
+	if list {
+		var l struct {
+			Data []struct {
+				Node json.RawMessage `json:"node"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &l); err != nil {
+			return nil, fmt.Errorf("can't read the list: %w", err)
+		}
+		for _, d := range l.Data {
+			bodies = append(bodies, d.Node)
+		}
+	} else {
+		bodies = append(bodies, body)
+	}
+
+	items := make(rawItems, 0, len(bodies))
+	for _, b := range bodies {
+		item := rawItem{body: b}
+		if err := json.Unmarshal(b, &item.keys); err != nil {
+			return nil, fmt.Errorf("can't read an anime: %w", err)
+		}
+		_ = json.Unmarshal(item.keys["id"], &item.id)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// has reports whether any of the items has the field, with a value.
+func (items rawItems) has(field string) bool {
+	for _, item := range items {
+		if v, ok := item.keys[field]; ok && string(v) != "null" {
+			return true
+		}
+	}
+	return false
+}
+
+func (items rawItems) keyFold(name string) (string, bool) {
+	for _, item := range items {
+		if _, ok := item.keys[name]; ok {
+			return name, true
+		}
+		for key := range item.keys {
+			if strings.EqualFold(key, name) {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// checkTags looks for tags the json package can't read, or that carry
+// options: the entities are only ever decoded, where options do nothing
+// but suggest otherwise.
+func checkTags(t reflect.Type, path string, seen map[reflect.Type]bool) []string {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return checkTags(t.Elem(), path, seen)
+	case reflect.Struct:
+	default:
+		return nil
+	}
+	// decoding is up to the type itself
+	if seen[t] || reflect.PointerTo(t).Implements(reflect.TypeFor[json.Unmarshaler]()) {
+		return nil
+	}
+	seen[t] = true
+
+	var problems []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := path + "." + f.Name
+		tag, ok := f.Tag.Lookup("json")
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s has no json tag the json package can read: `%s`", name, f.Tag))
+		} else if _, opts, _ := strings.Cut(tag, ","); opts != "" {
+			problems = append(problems, fmt.Sprintf("%s has the option %q, which does nothing when decoding", name, opts))
+		}
+		problems = append(problems, checkTags(f.Type, name, seen)...)
+	}
+	return problems
+}
@@ -3,28 +3,52 @@ package url
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/izzanzahrial/tui/entity"
 	"github.com/izzanzahrial/tui/message"
 )
 
+// detailFields are every field the Detail page, and its sub-views, may show.
+var detailFields = []string{
+	"id", "title", "main_picture", "alternative_titles",
+	"start_date", "end_date", "synopsis", "mean",
+	"rank", "popularity", "num_list_users", "num_scoring_users",
+	"nsfw", "created_at", "updated_at", "media_type",
+	"status", "genres", "num_episodes",
+	"start_season", "broadcast", "source", "average_episode_duration",
+	"rating", "pictures", "background", "related_anime",
+	"related_manga", "recommendations", "studios", "statistics",
+}
+
+// AnimeDetail serves the detail from the cache when it can, concurrent
+// calls for the same id, e.g. a prefetch and the detail page, share one request.
 func (c *Client) AnimeDetail(id int) (*entity.Detail, error) {
+	if d, ok := c.cache.get(id); ok {
+		return d, nil
+	}
+
+	v, err, _ := c.flight.Do(strconv.Itoa(id), func() (any, error) {
+		d, err := c.fetchDetail(id)
+		if err != nil {
+			return nil, err
+		}
+		c.cache.put(id, d)
+		return d, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*entity.Detail), nil
+}
+
+func (c *Client) fetchDetail(id int) (*entity.Detail, error) {
 	var airingAnimeUrl strings.Builder
 	airingAnimeUrl.WriteString(baseURL)
 	airingAnimeUrl.WriteString("/{id}")
 
-	fields := []string{
-		"id", "title", "main_picture", "alternative_titles",
-		"start_date", "end_date", "synopsis", "mean",
-		"rank", "popularity", "num_list_users", "num_scoring_users",
-		"nsfw", "created_at", "updated_at", "media_type",
-		"status", "genres", "num_episodes",
-		"start_season", "broadcast", "source", "average_episode_duration",
-		"rating", "pictures", "background", "related_anime",
-		"related_manga", "recommendations", "studios", "statistics",
-	}
-	fieldsString := strings.Join(fields, ",")
+	fieldsString := strings.Join(detailFields, ",")
 
 	// var data map[string]any
 	data := &entity.Detail{}
@@ -35,10 +59,14 @@ func (c *Client) AnimeDetail(id int) (*entity.Detail, error) {
 		SetQueryParam("fields", fieldsString).
 		SetResult(data)
 
-	_, err := request.Get(airingAnimeUrl.String())
+	res, err := request.Get(airingAnimeUrl.String())
 	if err != nil {
 		return nil, message.ErrMsg{Err: err}
 	}
+	// an error body decodes into an empty detail, which must not be cached
+	if res.IsError() {
+		return nil, message.ErrMsg{Err: fmt.Errorf("unexpected response: %s", res.Status())}
+	}
 
 	return data, nil
 }
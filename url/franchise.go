@@ -0,0 +1,83 @@
+package url
+
+import (
+	"strconv"
+
+	"github.com/izzanzahrial/tui/entity"
+)
+
+// The walk is bounded, long running series chain sequels for dozens of
+// entries and every one of them is a request.
+const (
+	franchiseMaxDepth   = 6
+	franchiseMaxEntries = 40
+)
+
+// skippedRelations point to other franchises rather than within one,
+// following them would pull in half of MAL.
+var skippedRelations = map[string]bool{
+	"Character": true,
+	"Other":     true,
+}
+
+// Franchise walks related_anime outwards from id, breadth first, so every
+// entry hangs off the closest relation to id. The details come from the
+// detail cache and the franchise is cached itself, keyed by where it started.
+func (c *Client) Franchise(id int) (*entity.Franchise, error) {
+	if f, ok := c.franchise.get(id); ok {
+		return f, nil
+	}
+
+	v, err, _ := c.flight.Do("franchise:"+strconv.Itoa(id), func() (any, error) {
+		f, err := c.walkFranchise(id)
+		if err != nil {
+			return nil, err
+		}
+		c.franchise.put(id, f)
+		return f, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*entity.Franchise), nil
+}
+
+func (c *Client) walkFranchise(root int) (*entity.Franchise, error) {
+	f := &entity.Franchise{Root: root}
+	seen := map[int]bool{root: true}
+	queue := []entity.FranchiseEntry{{ID: root}}
+
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+
+		d, err := c.AnimeDetail(e.ID)
+		if err != nil {
+			return nil, err
+		}
+		e.Title = d.Title
+		e.AlternativeTitle = d.AlternativeTitle
+		e.MediaType = d.MediaType
+		e.StartDate = d.StartDate
+		e.Episodes = d.Episodes
+		f.Entries = append(f.Entries, e)
+
+		for _, r := range d.RelatedAnimes {
+			if seen[r.Node.ID] || skippedRelations[r.RelationType] {
+				continue
+			}
+			if e.Depth >= franchiseMaxDepth || len(seen) >= franchiseMaxEntries {
+				f.Truncated = true
+				continue
+			}
+			seen[r.Node.ID] = true
+			queue = append(queue, entity.FranchiseEntry{
+				ID:       r.Node.ID,
+				Depth:    e.Depth + 1,
+				Parent:   e.ID,
+				Relation: r.RelationType,
+			})
+		}
+	}
+	return f, nil
+}
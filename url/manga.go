@@ -0,0 +1,160 @@
+package url
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+const defaultMangaRankType = "all"
+
+// mangaListFields are the extra fields needed to fill the manga rank table.
+var mangaListFields = []string{
+	"alternative_titles", "mean", "popularity", "num_list_users",
+	"num_volumes", "num_chapters", "media_type", "start_date",
+}
+
+// MangaRankingType is one of the manga rankings MAL offers, an unknown one falls back to all.
+type MangaRankingType int
+
+const (
+	MangaAll MangaRankingType = iota + 1
+	MangaOnly
+	Novels
+	OneShots
+	Doujinshi
+	Manhwa
+	Manhua
+	MangaByPopularity
+	MangaFavorite
+)
+
+var mangaRanks = map[MangaRankingType]string{
+	MangaAll:          "all",
+	MangaOnly:         "manga",
+	Novels:            "novels",
+	OneShots:          "oneshots",
+	Doujinshi:         "doujin",
+	Manhwa:            "manhwa",
+	Manhua:            "manhua",
+	MangaByPopularity: "bypopularity",
+	MangaFavorite:     "favorite",
+}
+
+func (c *Client) MangaRank(typeRank MangaRankingType, limit, offset *int) (*entity.MangaData, error) {
+	data := &entity.MangaData{}
+	clientID := os.Getenv("CLIENT_ID")
+	request := c.client.R().
+		SetHeader(ClientIDHeader, clientID).
+		SetResult(data)
+
+	if limit != nil && *limit > 0 {
+		request.SetQueryParam("limit", fmt.Sprintf("%d", *limit))
+	} else {
+		request.SetQueryParam("limit", defaultLimit)
+	}
+
+	if offset != nil && *offset > 0 {
+		request.SetQueryParam("offset", fmt.Sprintf("%d", *offset))
+	}
+
+	rankingType, ok := mangaRanks[typeRank]
+	if !ok {
+		rankingType = defaultMangaRankType
+	}
+	request.SetQueryParam("ranking_type", rankingType)
+
+	request.SetQueryParam("fields", strings.Join(mangaListFields, ","))
+
+	res, err := request.Get(mangaURL + "/ranking")
+	if err != nil {
+		return nil, message.ErrMsg{Err: err}
+	}
+	if res.IsError() {
+		return nil, message.ErrMsg{Err: fmt.Errorf("unexpected response: %s", res.Status())}
+	}
+
+	return data, nil
+}
+
+// MangaSearch looks manga up by title.
+func (c *Client) MangaSearch(query string, limit int) (*entity.MangaList, error) {
+	data := &entity.MangaList{}
+	clientID := os.Getenv("CLIENT_ID")
+	request := c.client.R().
+		SetHeader(ClientIDHeader, clientID).
+		SetQueryParam("q", query).
+		SetQueryParam("fields", strings.Join(mangaListFields, ",")).
+		SetResult(data)
+
+	if limit > 0 {
+		request.SetQueryParam("limit", strconv.Itoa(limit))
+	} else {
+		request.SetQueryParam("limit", defaultLimit)
+	}
+
+	res, err := request.Get(mangaURL)
+	if err != nil {
+		return nil, message.ErrMsg{Err: err}
+	}
+	if res.IsError() {
+		return nil, message.ErrMsg{Err: fmt.Errorf("unexpected response: %s", res.Status())}
+	}
+
+	return data, nil
+}
+
+// MangaDetail is cached and deduplicated like AnimeDetail.
+func (c *Client) MangaDetail(id int) (*entity.MangaDetail, error) {
+	if d, ok := c.manga.get(id); ok {
+		return d, nil
+	}
+
+	v, err, _ := c.flight.Do("manga:"+strconv.Itoa(id), func() (any, error) {
+		d, err := c.fetchMangaDetail(id)
+		if err != nil {
+			return nil, err
+		}
+		c.manga.put(id, d)
+		return d, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*entity.MangaDetail), nil
+}
+
+func (c *Client) fetchMangaDetail(id int) (*entity.MangaDetail, error) {
+	fields := []string{
+		"id", "title", "main_picture", "alternative_titles",
+		"start_date", "end_date", "synopsis", "mean",
+		"rank", "popularity", "num_list_users", "num_scoring_users",
+		"nsfw", "media_type", "status", "genres",
+		"num_volumes", "num_chapters", "authors{first_name,last_name}",
+		"pictures", "background", "related_anime", "related_manga",
+		"recommendations", "serialization{name}",
+	}
+
+	data := &entity.MangaDetail{}
+	clientID := os.Getenv("CLIENT_ID")
+	request := c.client.R().
+		SetHeader(ClientIDHeader, clientID).
+		SetPathParam("id", strconv.Itoa(id)).
+		SetQueryParam("fields", strings.Join(fields, ",")).
+		SetResult(data)
+
+	res, err := request.Get(mangaURL + "/{id}")
+	if err != nil {
+		return nil, message.ErrMsg{Err: err}
+	}
+	// an error body decodes into an empty detail, which must not be cached
+	if res.IsError() {
+		return nil, message.ErrMsg{Err: fmt.Errorf("unexpected response: %s", res.Status())}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,26 @@
+package url
+
+import (
+	"fmt"
+
+	"github.com/izzanzahrial/tui/message"
+)
+
+// pictureLimit bounds a single download, MAL's large pictures are well
+// below it.
+const pictureLimit = 8 << 20
+
+// Picture downloads one of the anime pictures, link is the full url MAL
+// sent in the pictures field.
+func (c *Client) Picture(link string) ([]byte, error) {
+	res, err := c.client.R().
+		SetResponseBodyLimit(pictureLimit).
+		Get(link)
+	if err != nil {
+		return nil, message.ErrMsg{Err: err}
+	}
+	if res.IsError() {
+		return nil, message.ErrMsg{Err: fmt.Errorf("unexpected response: %s", res.Status())}
+	}
+	return res.Bytes(), nil
+}
@@ -0,0 +1,56 @@
+package url
+
+import "sync"
+
+// maxPrefetchWorkers bounds how many speculative requests run at once,
+// they still go through the client's rate limiter like any other request.
+const maxPrefetchWorkers = 2
+
+// prefetcher fetches details in the background. Only the latest wish list
+// matters, when the cursor moves on the rows left behind are dropped.
+type prefetcher struct {
+	mu      sync.Mutex
+	queue   []int
+	running int
+}
+
+// PrefetchDetail fetches the details of ids in the background, in order,
+// so a later AnimeDetail is served from the cache. It replaces whatever
+// was still waiting from a previous call and never blocks.
+func (c *Client) PrefetchDetail(ids ...int) {
+	p := c.prefetch
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.queue = p.queue[:0]
+	for _, id := range ids {
+		if _, ok := c.cache.get(id); !ok {
+			p.queue = append(p.queue, id)
+		}
+	}
+
+	for p.running < maxPrefetchWorkers && p.running < len(p.queue) {
+		p.running++
+		go c.prefetchWorker()
+	}
+}
+
+func (c *Client) prefetchWorker() {
+	p := c.prefetch
+	for {
+		p.mu.Lock()
+		if len(p.queue) == 0 {
+			p.running--
+			p.mu.Unlock()
+			return
+		}
+		id := p.queue[0]
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+
+		// failures are already logged by the client, and the detail page
+		// will simply try again when it's opened
+		_, _ = c.AnimeDetail(id)
+	}
+}
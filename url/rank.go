@@ -14,30 +14,47 @@ const (
 	defaultRankType = "airing"
 )
 
+// rankFields are the extra fields needed to fill every column of the rank table.
+var rankFields = []string{
+	"alternative_titles", "mean", "popularity", "num_list_users",
+	"num_episodes", "media_type", "start_season", "broadcast",
+}
+
+// RankingType is one of the rankings MAL offers, an unknown one falls back to airing.
 type RankingType int
 
 const (
-	all RankingType = iota + 1
-	airing
-	upcoming
-	tv
-	ova
-	movie
-	special
-	bypopularity
-	favorite
+	All RankingType = iota + 1
+	Airing
+	Upcoming
+	TV
+	OVA
+	Movie
+	Special
+	ByPopularity
+	Favorite
 )
 
 var ranks = map[RankingType]string{
-	all:          "all",
-	airing:       "airing",
-	upcoming:     "upcoming",
-	tv:           "tv",
-	ova:          "ova",
-	movie:        "movie",
-	special:      "special",
-	bypopularity: "bypopularity",
-	favorite:     "favorite",
+	All:          "all",
+	Airing:       "airing",
+	Upcoming:     "upcoming",
+	TV:           "tv",
+	OVA:          "ova",
+	Movie:        "movie",
+	Special:      "special",
+	ByPopularity: "bypopularity",
+	Favorite:     "favorite",
+}
+
+// ParseRankingType is the RankingType MAL calls name, e.g. "bypopularity".
+func ParseRankingType(name string) (RankingType, bool) {
+	for t, n := range ranks {
+		if n == name {
+			return t, true
+		}
+	}
+	return 0, false
 }
 
 func (c *Client) AnimeRank(typeRank RankingType, limit, offset *int) (*entity.Data, error) {
@@ -67,12 +84,15 @@ func (c *Client) AnimeRank(typeRank RankingType, limit, offset *int) (*entity.Da
 	}
 	request.SetQueryParam("ranking_type", rankingType)
 
-	request.SetQueryParam("fields", "alternative_titles")
+	request.SetQueryParam("fields", strings.Join(rankFields, ","))
 
-	_, err := request.Get(airingAnimeUrl.String())
+	res, err := request.Get(airingAnimeUrl.String())
 	if err != nil {
 		return nil, message.ErrMsg{Err: err}
 	}
+	if res.IsError() {
+		return nil, message.ErrMsg{Err: fmt.Errorf("unexpected response: %s", res.Status())}
+	}
 
 	return data, nil
 }
@@ -0,0 +1,38 @@
+package url
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+// AnimeSearch looks anime up by title, with the same fields as the ranking.
+func (c *Client) AnimeSearch(query string, limit int) (*entity.AnimeList, error) {
+	data := &entity.AnimeList{}
+	clientID := os.Getenv("CLIENT_ID")
+	request := c.client.R().
+		SetHeader(ClientIDHeader, clientID).
+		SetQueryParam("q", query).
+		SetQueryParam("fields", strings.Join(rankFields, ",")).
+		SetResult(data)
+
+	if limit > 0 {
+		request.SetQueryParam("limit", strconv.Itoa(limit))
+	} else {
+		request.SetQueryParam("limit", defaultLimit)
+	}
+
+	res, err := request.Get(baseURL)
+	if err != nil {
+		return nil, message.ErrMsg{Err: err}
+	}
+	if res.IsError() {
+		return nil, message.ErrMsg{Err: fmt.Errorf("unexpected response: %s", res.Status())}
+	}
+
+	return data, nil
+}
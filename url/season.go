@@ -0,0 +1,64 @@
+package url
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/izzanzahrial/tui/entity"
+	"github.com/izzanzahrial/tui/message"
+)
+
+// Seasons are the ones MAL groups anime by, in the order of the year.
+var Seasons = []string{"winter", "spring", "summer", "fall"}
+
+// SeasonSort orders a seasonal list, MAL leaves it unordered otherwise.
+type SeasonSort int
+
+const (
+	ByScore SeasonSort = iota + 1
+	ByMembers
+)
+
+var seasonSorts = map[SeasonSort]string{
+	ByScore:   "anime_score",
+	ByMembers: "anime_num_list_users",
+}
+
+// AnimeSeason lists the anime that started in season of year, e.g. 2024
+// and "fall".
+func (c *Client) AnimeSeason(year int, season string, sort SeasonSort, limit, offset int) (*entity.AnimeList, error) {
+	data := &entity.AnimeList{}
+	clientID := os.Getenv("CLIENT_ID")
+	request := c.client.R().
+		SetHeader(ClientIDHeader, clientID).
+		SetPathParam("year", strconv.Itoa(year)).
+		SetPathParam("season", season).
+		SetQueryParam("fields", strings.Join(rankFields, ",")).
+		SetResult(data)
+
+	if limit > 0 {
+		request.SetQueryParam("limit", strconv.Itoa(limit))
+	} else {
+		request.SetQueryParam("limit", defaultLimit)
+	}
+
+	if offset > 0 {
+		request.SetQueryParam("offset", strconv.Itoa(offset))
+	}
+
+	if s, ok := seasonSorts[sort]; ok {
+		request.SetQueryParam("sort", s)
+	}
+
+	res, err := request.Get(baseURL + "/season/{year}/{season}")
+	if err != nil {
+		return nil, message.ErrMsg{Err: err}
+	}
+	if res.IsError() {
+		return nil, message.ErrMsg{Err: fmt.Errorf("unexpected response: %s", res.Status())}
+	}
+
+	return data, nil
+}
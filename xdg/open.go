@@ -0,0 +1,27 @@
+package xdg
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// Open hands target, a file or an url, to the desktop's default
+// application without waiting for it to exit.
+func Open(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// reap it whenever it's done, nobody is interested in how it went
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,42 @@
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// AppName is the directory name used under every XDG base directory.
+const AppName = "anime-tui"
+
+// DataDir returns $XDG_DATA_HOME/anime-tui, falling back to ~/.local/share/anime-tui.
+func DataDir() string {
+	return dir("XDG_DATA_HOME", ".local", "share")
+}
+
+// ConfigDir returns $XDG_CONFIG_HOME/anime-tui, falling back to ~/.config/anime-tui.
+func ConfigDir() string {
+	return dir("XDG_CONFIG_HOME", ".config")
+}
+
+// StateDir returns $XDG_STATE_HOME/anime-tui, falling back to ~/.local/state/anime-tui.
+func StateDir() string {
+	return dir("XDG_STATE_HOME", ".local", "state")
+}
+
+// PicturesDir returns where saved pictures go, $XDG_DATA_HOME/anime-tui/pictures
+// falling back to ~/.local/share/anime-tui/pictures.
+func PicturesDir() string {
+	return filepath.Join(DataDir(), "pictures")
+}
+
+func dir(env string, fallback ...string) string {
+	base := os.Getenv(env)
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = "."
+		}
+		base = filepath.Join(append([]string{home}, fallback...)...)
+	}
+	return filepath.Join(base, AppName)
+}